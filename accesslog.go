@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// accessLogger returns the access log middleware, or nil when --access-log
+// is off. It logs one record per request to log, the logger shared with the
+// rest of the monitor. Requests under an --access-log-skip prefix, such as
+// probes and scrapes, are left out.
+func accessLogger(cfg *Config, log *slog.Logger) fiber.Handler {
+	if !cfg.AccessLog {
+		return nil
+	}
+	unixSocket := cfg.UnixSocket != ""
+
+	// Like Fiber's logger, this runs the error handler itself so the record
+	// carries the status the client is sent
+	return func(c *fiber.Ctx) error {
+		if hasPathPrefix(c.Path(), cfg.AccessLogSkip) {
+			return c.Next()
+		}
+
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		// Reading a streamed body, such as an SSE stream's, would drain it
+		// here, so like Fiber's logger those count as zero bytes
+		size := 0
+		if !c.Response().IsBodyStream() {
+			size = len(c.Response().Body())
+		}
+		attrs := []any{
+			"method", c.Method(),
+			"path", c.Path(),
+			"status", c.Response().StatusCode(),
+			"latencyMs", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", size,
+		}
+		// Unix socket peers have no address
+		if !unixSocket {
+			attrs = append(attrs, "ip", c.IP())
+		}
+		log.Info("http request", attrs...)
+		return nil
+	}
+}
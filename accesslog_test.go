@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAccessLog(t *testing.T) {
+	cfg, err := loadTestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	s := newServer(cfg, nil, slog.New(slog.NewJSONHandler(&buf, nil)), fakeSource{})
+	t.Cleanup(s.cancel)
+
+	resp, err := s.app.Test(httptest.NewRequest("GET", "/api/annotations", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	var record struct {
+		Msg    string `json:"msg"`
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Status int    `json:"status"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("access log %q: %v", buf.String(), err)
+	}
+	if record.Msg != "http request" || record.Method != "GET" || record.Path != "/api/annotations" || record.Status != resp.StatusCode {
+		t.Errorf("access log record = %+v, want GET /api/annotations %d", record, resp.StatusCode)
+	}
+}
+
+// TestAccessLogStream checks a streamed response is left to the client
+// rather than read in the access log, which would hold an SSE stream
+// until it ended
+func TestAccessLogStream(t *testing.T) {
+	var buf bytes.Buffer
+	app := fiber.New()
+	app.Use(accessLogger(&Config{AccessLog: true}, slog.New(slog.NewJSONHandler(&buf, nil))))
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			w.WriteString("streamed")
+		})
+		return nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/stream", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "streamed" {
+		t.Errorf("body = %q, want %q", body, "streamed")
+	}
+
+	var record struct {
+		Bytes int `json:"bytes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("access log %q: %v", buf.String(), err)
+	}
+	if record.Bytes != 0 {
+		t.Errorf("access log bytes = %d, want 0 for a stream", record.Bytes)
+	}
+}
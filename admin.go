@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"system-monitor/templates"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (s *Server) adminHandler(c *fiber.Ctx) error {
+	return renderHTML(c, templates.Admin())
+}
+
+func (s *Server) adminUsageHandler(c *fiber.Ctx) error {
+	stats, err := s.telemetry.Usage()
+	if err != nil {
+		return err
+	}
+	return renderHTML(c, templates.UsageData(stats, s.subscriberCount()))
+}
+
+// SubscriberInfo describes one connected stream client. Hostname is only
+// set with --resolve-subscribers: the reverse DNS name of the remote
+// address, or "loopback" or "private" for addresses that aren't looked up.
+type SubscriberInfo struct {
+	RemoteAddr  string    `json:"remoteAddr"`
+	Hostname    string    `json:"hostname,omitempty"`
+	Transport   string    `json:"transport"`
+	Format      string    `json:"format"`
+	Protocol    string    `json:"protocol,omitempty"`
+	Compressed  bool      `json:"compressed,omitempty"`
+	ConnectedAt time.Time `json:"connectedAt"`
+	Paused      bool      `json:"paused"`
+}
+
+// SubscriberList is the /api/subscribers response
+type SubscriberList struct {
+	Count       int              `json:"count"`
+	Subscribers []SubscriberInfo `json:"subscribers"`
+}
+
+func (s *Server) subscribersHandler(c *fiber.Ctx) error {
+	list := s.subscriberList()
+	if s.getConfig().ResolveDNS {
+		s.reverseDNS.annotate(c.Context(), list.Subscribers)
+	}
+	return c.JSON(list)
+}
+
+// subscriberList snapshots the connected subscribers, oldest first
+func (s *Server) subscriberList() SubscriberList {
+	s.subscribersMu.Lock()
+	infos := make([]SubscriberInfo, 0, len(s.subscribers))
+	for subscriber := range s.subscribers {
+		infos = append(infos, SubscriberInfo{
+			RemoteAddr:  subscriber.remoteAddr,
+			Transport:   subscriber.transport,
+			Format:      subscriber.format,
+			Protocol:    subscriber.protocol,
+			Compressed:  subscriber.compressed,
+			ConnectedAt: subscriber.connectedAt,
+			Paused:      subscriber.paused,
+		})
+	}
+	s.subscribersMu.Unlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ConnectedAt.Before(infos[j].ConnectedAt)
+	})
+
+	return SubscriberList{
+		Count:       len(infos),
+		Subscribers: infos,
+	}
+}
+
+// renderHTML renders a component as the HTML response body
+func renderHTML(c *fiber.Ctx, component templ.Component) error {
+	var buf bytes.Buffer
+	if err := component.Render(c.Context(), &buf); err != nil {
+		return err
+	}
+	c.Set("Content-Type", "text/html")
+	return c.SendString(buf.String())
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"system-monitor/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxAnnotationLabel bounds an annotation's label in characters
+const maxAnnotationLabel = 200
+
+// AnnotationRequest is the body of POST /api/annotations. Timestamp
+// defaults to now.
+type AnnotationRequest struct {
+	Label     string     `json:"label"`
+	Timestamp *time.Time `json:"timestamp"`
+}
+
+// HistoryResponse is returned by /api/history?annotations=true
+type HistoryResponse struct {
+	Samples     []metrics.Sample     `json:"samples"`
+	Annotations []metrics.Annotation `json:"annotations"`
+}
+
+// annotateHandler records a timeline event, such as a deployment, in the
+// history store
+func (s *Server) annotateHandler(c *fiber.Ctx) error {
+	var req AnnotationRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return newAPIError(fiber.StatusBadRequest, "invalid annotation", err.Error())
+	}
+	req.Label = strings.TrimSpace(req.Label)
+	if req.Label == "" || len([]rune(req.Label)) > maxAnnotationLabel {
+		return newAPIError(fiber.StatusBadRequest, "invalid 'label'", fmt.Sprintf("must be 1 to %d characters", maxAnnotationLabel))
+	}
+
+	annotation := metrics.Annotation{Time: time.Now(), Label: req.Label}
+	if req.Timestamp != nil {
+		annotation.Time = *req.Timestamp
+	}
+
+	if s.store != nil {
+		if err := s.store.InsertAnnotation(annotation); err != nil {
+			return newAPIError(fiber.StatusInternalServerError, "saving annotation failed", err.Error())
+		}
+	} else {
+		s.annotations.Add(annotation)
+	}
+
+	annotation.Time = annotation.Time.In(s.getConfig().Location)
+	return c.Status(fiber.StatusCreated).JSON(annotation)
+}
+
+// annotationsHandler lists the annotations in the 'from' and 'to' range
+func (s *Server) annotationsHandler(c *fiber.Ctx) error {
+	from, to, err := parseRange(c, defaultHistoryWindow)
+	if err != nil {
+		return err
+	}
+	annotations, err := s.annotationRange(from, to)
+	if err != nil {
+		return err
+	}
+	return c.JSON(annotations)
+}
+
+// annotationRange reads annotations from the store the history is kept in
+func (s *Server) annotationRange(from, to time.Time) ([]metrics.Annotation, error) {
+	if s.store == nil {
+		return s.annotations.Range(from, to), nil
+	}
+	annotations, err := s.store.AnnotationRange(from, to)
+	if err != nil {
+		return nil, newAPIError(fiber.StatusInternalServerError, "reading annotations failed", err.Error())
+	}
+	return annotations, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"system-monitor/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const defaultHistoryWindow = time.Hour
+
+// setLatest stores the most recently collected snapshot and records it for
+// /api/metrics?since= under the next sequence number
+func (s *Server) setLatest(snapshot *metrics.Snapshot) {
+	s.latestMu.Lock()
+	defer s.latestMu.Unlock()
+	s.latest = snapshot
+	s.latestSeq++
+	s.deltas.add(s.latestSeq, s.apiSnapshot(snapshot))
+}
+
+// getLatest returns the most recently collected snapshot, or nil before the
+// first tick
+func (s *Server) getLatest() *metrics.Snapshot {
+	s.latestMu.RLock()
+	defer s.latestMu.RUnlock()
+	return s.latest
+}
+
+// getLatestSeq returns the most recent snapshot with its sequence number
+func (s *Server) getLatestSeq() (*metrics.Snapshot, uint64) {
+	s.latestMu.RLock()
+	defer s.latestMu.RUnlock()
+	return s.latest, s.latestSeq
+}
+
+// metricsHandler returns the latest snapshot with its token in the
+// X-Metrics-Token header. With ?since=<token> it returns a MetricsDelta of
+// the fields that changed since that snapshot instead.
+func (s *Server) metricsHandler(c *fiber.Ctx) error {
+	s.wakeForPull(c)
+	snapshot, seq := s.getLatestSeq()
+	if snapshot == nil {
+		return newAPIError(fiber.StatusServiceUnavailable, "no metrics collected yet", "")
+	}
+	c.Set(metricsTokenHeader, s.deltas.token(seq))
+
+	if since := c.Query("since"); since != "" {
+		return c.JSON(s.deltas.since(since, seq))
+	}
+
+	view := c.Query("cpu", metrics.CPUViewBoth)
+	if !slices.Contains(metrics.CPUViews, view) {
+		return newAPIError(fiber.StatusBadRequest, "invalid 'cpu' parameter", "must be cores, avg or both")
+	}
+	counters := c.Query("counters", metrics.CounterViewBoth)
+	if !slices.Contains(metrics.CounterViews, counters) {
+		return newAPIError(fiber.StatusBadRequest, "invalid 'counters' parameter", "must be total, rate or both")
+	}
+	return c.JSON(s.apiSnapshot(snapshot.WithCPUView(view).WithCounterView(counters)))
+}
+
+func (s *Server) processesHandler(c *fiber.Ctx) error {
+	s.wakeForPull(c)
+	snapshot := s.getLatest()
+	if snapshot == nil {
+		return newAPIError(fiber.StatusServiceUnavailable, "no metrics collected yet", "")
+	}
+	if snapshot.Processes == nil {
+		return newAPIError(fiber.StatusNotFound, "process panel disabled", "not included in --panels")
+	}
+	return c.JSON(snapshot.Rounded(s.getConfig().JSONPrecision).Processes)
+}
+
+func (s *Server) historyHandler(c *fiber.Ctx) error {
+	from, to, err := parseRange(c, defaultHistoryWindow)
+	if err != nil {
+		return err
+	}
+
+	var resolution time.Duration
+	if v := c.Query("resolution"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return newAPIError(fiber.StatusBadRequest, "invalid 'resolution' parameter", "must be a positive duration such as 1m")
+		}
+		resolution = d
+	}
+
+	var samples []metrics.Sample
+	if s.store != nil {
+		samples, err = s.store.Range(from, to)
+		if err != nil {
+			return newAPIError(fiber.StatusInternalServerError, "reading history failed", err.Error())
+		}
+	} else {
+		samples = s.history.Range(from, to)
+	}
+
+	if resolution > 0 {
+		samples = metrics.Downsample(samples, resolution)
+	}
+	metrics.RoundSamples(samples, s.getConfig().JSONPrecision)
+
+	// ?annotations=true wraps the samples with the events in the same range
+	if withAnnotations, _ := strconv.ParseBool(c.Query("annotations")); withAnnotations {
+		annotations, err := s.annotationRange(from, to)
+		if err != nil {
+			return err
+		}
+		return c.JSON(HistoryResponse{Samples: samples, Annotations: annotations})
+	}
+	return c.JSON(samples)
+}
+
+// parseRange reads the 'from' and 'to' query parameters, defaulting to the
+// window ending now
+func parseRange(c *fiber.Ctx, window time.Duration) (time.Time, time.Time, error) {
+	to := time.Now()
+	if v := c.Query("to"); v != "" {
+		t, err := parseTimeParam(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, newAPIError(fiber.StatusBadRequest, "invalid 'to' parameter", err.Error())
+		}
+		to = t
+	}
+
+	from := to.Add(-window)
+	if v := c.Query("from"); v != "" {
+		t, err := parseTimeParam(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, newAPIError(fiber.StatusBadRequest, "invalid 'from' parameter", err.Error())
+		}
+		from = t
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, newAPIError(fiber.StatusBadRequest, "invalid time range", "'from' must not be after 'to'")
+	}
+	return from, to, nil
+}
+
+// parseTimeParam accepts either an RFC 3339 timestamp or unix seconds
+func parseTimeParam(v string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
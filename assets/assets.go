@@ -0,0 +1,9 @@
+package assets
+
+import "embed"
+
+// Static holds the frontend files served under /static, bundled into the
+// binary so deployment stays a single file
+//
+//go:embed static
+var Static embed.FS
@@ -0,0 +1,38 @@
+// Switches the network and disk I/O panels between the counters since boot,
+// the current rates, or both. Frames carry both, so the choice is kept in
+// this browser alone; ?counters= in the dashboard URL overrides it.
+(function () {
+	var views = ['both', 'total', 'rate'];
+	var storageKey = 'monitor.counters';
+
+	function apply(view) {
+		if (views.indexOf(view) < 0) {
+			view = 'both';
+		}
+		document.documentElement.dataset.counters = view;
+		document.querySelectorAll('[data-counters-set]').forEach(function (button) {
+			button.classList.toggle('bg-gray-600', button.dataset.countersSet === view);
+		});
+	}
+
+	var stored = null;
+	try {
+		stored = localStorage.getItem(storageKey);
+	} catch (e) {
+		// Storage is unavailable in some private browsing modes
+	}
+	apply(new URLSearchParams(location.search).get('counters') || stored || 'both');
+
+	document.addEventListener('click', function (event) {
+		var button = event.target.closest('[data-counters-set]');
+		if (!button) {
+			return;
+		}
+		apply(button.dataset.countersSet);
+		try {
+			localStorage.setItem(storageKey, button.dataset.countersSet);
+		} catch (e) {
+			// The choice still holds until the page is reloaded
+		}
+	});
+})();
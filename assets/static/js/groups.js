@@ -0,0 +1,26 @@
+// Keeps the process groups the user expanded open. Every frame replaces the
+// process table, which would otherwise collapse them again.
+(function () {
+	var open = {};
+
+	// toggle doesn't bubble, so listen in the capture phase
+	document.addEventListener('toggle', function (event) {
+		var key = event.target.dataset && event.target.dataset.group;
+		if (key === undefined) {
+			return;
+		}
+		if (event.target.open) {
+			open[key] = true;
+		} else {
+			delete open[key];
+		}
+	}, true);
+
+	document.addEventListener('htmx:oobAfterSwap', function () {
+		document.querySelectorAll('details[data-group]').forEach(function (details) {
+			if (open[details.dataset.group]) {
+				details.open = true;
+			}
+		});
+	});
+})();
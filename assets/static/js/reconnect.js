@@ -0,0 +1,20 @@
+// Reconnects the websocket with capped exponential backoff and shows a banner
+// while the live stream is down. The server sends the latest frame as soon as
+// a client connects, so the dashboard refreshes without waiting for a tick.
+(function () {
+	var maxDelay = 30000;
+	htmx.config.wsReconnectDelay = function (retryCount) {
+		var delay = Math.min(maxDelay, 1000 * Math.pow(2, retryCount));
+		return delay / 2 + Math.random() * delay / 2;
+	};
+
+	var banner = document.getElementById('connection-banner');
+	function setConnected(connected) {
+		banner.classList.toggle('hidden', connected);
+	}
+
+	document.addEventListener('htmx:wsOpen', function () { setConnected(true); });
+	document.addEventListener('htmx:wsClose', function () { setConnected(false); });
+	document.addEventListener('htmx:sseOpen', function () { setConnected(true); });
+	document.addEventListener('htmx:sseError', function () { setConnected(false); });
+})();
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
+)
+
+const adminRealm = "System Monitor admin"
+
+// adminAuth guards the admin routes with basic auth when --admin-password
+// is set. Without a password, routes marked required are refused outright
+// while the rest stay open as before.
+func (s *Server) adminAuth(required bool) fiber.Handler {
+	cfg := s.getConfig()
+	if cfg.AdminPassword == "" {
+		return func(c *fiber.Ctx) error {
+			if required {
+				return newAPIError(fiber.StatusForbidden, "admin credentials not configured", "start the monitor with --admin-password to enable this endpoint")
+			}
+			return c.Next()
+		}
+	}
+
+	return basicauth.New(basicauth.Config{
+		Users: map[string]string{
+			cfg.AdminUser: cfg.AdminPassword,
+		},
+		Realm: adminRealm,
+		Unauthorized: func(c *fiber.Ctx) error {
+			c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="`+adminRealm+`"`)
+			return newAPIError(fiber.StatusUnauthorized, "unauthorized", "admin credentials required")
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"system-monitor/console"
+)
+
+// benchmarkTarget is one collector timed by --benchmark
+type benchmarkTarget struct {
+	name string
+	run  func() error
+}
+
+// collectOnce runs the collector's source once without caching the result
+func (c *collector[T]) collectOnce() error {
+	_, err := c.collect()
+	return err
+}
+
+// benchmarkTargets lists the collectors enabled on this host
+func (c *collectors) benchmarkTargets() []benchmarkTarget {
+	var targets []benchmarkTarget
+	for _, r := range c.all() {
+		targets = append(targets, benchmarkTarget{r.collectorName(), r.collectOnce})
+	}
+	return targets
+}
+
+// runBenchmark times every collector runs times and prints min/avg/max
+// latency as a table, so a safe --interval can be chosen for the hardware
+func (s *Server) runBenchmark(runs int) {
+	console.Printf(console.Timer, "Benchmarking collectors (%d runs each)", runs)
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COLLECTOR\tRUNS\tERRORS\tMIN\tAVG\tMAX")
+
+	var slowest time.Duration
+	for _, target := range s.collectors.benchmarkTargets() {
+		var total, minimum, maximum time.Duration
+		errors := 0
+		for i := 0; i < runs; i++ {
+			start := time.Now()
+			if err := target.run(); err != nil {
+				errors++
+			}
+			elapsed := time.Since(start)
+
+			total += elapsed
+			if i == 0 || elapsed < minimum {
+				minimum = elapsed
+			}
+			maximum = max(maximum, elapsed)
+		}
+		slowest = max(slowest, maximum)
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n",
+			target.name,
+			runs,
+			errors,
+			roundLatency(minimum),
+			roundLatency(total/time.Duration(runs)),
+			roundLatency(maximum),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\nSlowest collection took %s; keep --interval and --intervals above it\n", roundLatency(slowest))
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
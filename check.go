@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"system-monitor/metrics"
+)
+
+// --check exit codes, following the Nagios plugin convention
+const (
+	checkOK       = 0
+	checkWarning  = 1
+	checkCritical = 2
+	checkUnknown  = 3
+)
+
+// checkWindow separates the two collections of --check, so CPU usage and
+// rates are measured over a known window rather than since startup
+const checkWindow = time.Second
+
+// runCheck collects, rates CPU, memory and disk against the health
+// thresholds and prints a plugin-style summary line, e.g.
+//
+//	WARNING - memory at 84.2% | cpu=12.5%;75;90 memory=84.2%;80;95 disk=48.0%;80;90
+//
+// It returns the exit code for the worst status, or checkUnknown when the
+// metrics could not be collected.
+func (s *Server) runCheck() int {
+	s.collectors.refresh()
+	time.Sleep(checkWindow)
+	s.collectors.refresh()
+	snapshot, err := s.collectSnapshot()
+	if err != nil {
+		fmt.Printf("UNKNOWN - collecting metrics: %v\n", err)
+		return checkUnknown
+	}
+
+	thresholds := s.getConfig().Health
+	health := metrics.EvaluateHealth(snapshot.System, snapshot.CPU, snapshot.Disk, thresholds)
+	sample := snapshot.Sample()
+
+	summary := "all metrics below their warning thresholds"
+	if health.Metric != "" {
+		summary = fmt.Sprintf("%s at %.1f%%", health.Metric, health.Value)
+	}
+
+	var perfdata []string
+	perf := func(label string, value float64, t metrics.Threshold) {
+		perfdata = append(perfdata, fmt.Sprintf("%s=%.1f%%;%g;%g", label, value, t.Warning, t.Critical))
+	}
+	if snapshot.CPU != nil {
+		perf("cpu", sample.CPUPercent, thresholds.CPU)
+	}
+	if snapshot.System != nil {
+		perf("memory", sample.MemUsedPercent, thresholds.Memory)
+	}
+	if snapshot.Disk != nil {
+		perf("disk", sample.DiskUsedPercent, thresholds.Disk)
+	}
+
+	line := strings.ToUpper(health.Status.String()) + " - " + summary
+	if len(perfdata) > 0 {
+		line += " | " + strings.Join(perfdata, " ")
+	}
+	fmt.Println(line)
+	switch health.Status {
+	case metrics.HealthCritical:
+		return checkCritical
+	case metrics.HealthWarning:
+		return checkWarning
+	default:
+		return checkOK
+	}
+}
@@ -0,0 +1,497 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+)
+
+// Collector names accepted by --intervals
+const (
+	collectorSystem    = "system"
+	collectorCPU       = "cpu"
+	collectorDisk      = "disk"
+	collectorNetwork   = "network"
+	collectorProcesses = "processes"
+	collectorLoad      = "load"
+	collectorNUMA      = "numa"
+	collectorSwap      = "swap"
+	collectorPressure  = "pressure"
+	collectorFD        = "fd"
+	collectorClock     = "clock"
+	collectorDocker    = "docker"
+	collectorDirs      = "dirs"
+	collectorSmart     = "smart"
+	collectorCustom    = "custom"
+)
+
+// defaultDirsInterval is the cadence for --watch-dir walks, which are too
+// expensive to repeat every tick
+const defaultDirsInterval = time.Minute
+
+// defaultSmartInterval is the cadence for --smart queries; SMART data
+// changes slowly and querying can wake idle disks
+const defaultSmartInterval = 5 * time.Minute
+
+// defaultClockInterval is the cadence for clock sync checks, which with
+// --ntp-server send a query to it
+const defaultClockInterval = time.Minute
+
+// ntpTimeout bounds each --ntp-server query, so an unreachable server is
+// reported instead of holding a collector slot
+const ntpTimeout = 2 * time.Second
+
+var collectorNames = []string{
+	collectorSystem,
+	collectorCPU,
+	collectorDisk,
+	collectorNetwork,
+	collectorProcesses,
+	collectorLoad,
+	collectorNUMA,
+	collectorSwap,
+	collectorPressure,
+	collectorFD,
+	collectorClock,
+	collectorDocker,
+	collectorDirs,
+	collectorSmart,
+	collectorCustom,
+}
+
+// collector runs one metric source on its own cadence and caches the most
+// recent successful result, so every frame carries the latest known value
+// even when the source was not refreshed during that publish cycle
+type collector[T any] struct {
+	name     string
+	interval atomic.Int64
+	collect  func() (T, error)
+	// slots, when set, is shared by every collector to bound how many
+	// collect at once
+	slots chan struct{}
+	// gate, when set, holds the collector back while no one is watching
+	gate *idleGate
+
+	mu    sync.RWMutex
+	value T
+	ready bool
+	// refused is set while collections fail for lack of privileges
+	refused bool
+	// errors throttles the log lines of a collection failing every tick
+	errors *errorThrottle
+}
+
+func newCollector[T any](name string, interval time.Duration, collect func() (T, error)) *collector[T] {
+	c := &collector[T]{
+		name:    name,
+		collect: collect,
+		errors:  newErrorThrottle("getting " + name + " data"),
+	}
+	c.setInterval(interval)
+	return c
+}
+
+// setInterval changes the cadence, taking effect after the next collection
+func (c *collector[T]) setInterval(interval time.Duration) {
+	c.interval.Store(int64(interval))
+}
+
+// start collects immediately and then once per interval
+func (c *collector[T]) start() {
+	go func() {
+		interval := time.Duration(c.interval.Load())
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if next := time.Duration(c.interval.Load()); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+
+			c.refresh()
+			<-ticker.C
+			// Resuming from --idle-pause refreshes every collector, so the
+			// next collection is an interval on
+			if c.gate.wait() {
+				ticker.Reset(interval)
+				<-ticker.C
+			}
+		}
+	}()
+}
+
+// refresh collects once and caches the result; transient errors are
+// retried and a persistent failure is logged, throttled while it repeats,
+// keeping the previous value.
+// Permission errors are logged once rather than every tick and mark the
+// collector denied until a collection succeeds.
+func (c *collector[T]) refresh() {
+	value, err := collectWithRetry(c.collectLimited)
+	if err != nil && isPermissionDenied(err) {
+		c.mu.Lock()
+		first := !c.refused
+		c.refused = true
+		c.mu.Unlock()
+		if first {
+			console.Printf(console.Warning, "Permission denied reading %s data; run the monitor with elevated privileges to collect it: %v", c.name, err)
+		}
+		return
+	}
+	if err != nil {
+		c.errors.failed(err)
+		return
+	}
+	c.errors.cleared()
+	c.mu.Lock()
+	c.value = value
+	c.ready = true
+	c.refused = false
+	c.mu.Unlock()
+}
+
+// collectLimited runs one collection, first waiting for a free slot when
+// --collector-concurrency bounds them
+func (c *collector[T]) collectLimited() (T, error) {
+	if c.slots != nil {
+		c.slots <- struct{}{}
+		defer func() { <-c.slots }()
+	}
+	return c.collect()
+}
+
+// latest returns the most recent value and whether one has been collected
+func (c *collector[T]) latest() (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.value, c.ready
+}
+
+// collectors groups the per-source collectors feeding the publisher
+type collectors struct {
+	system    *collector[*handlers.SystemInfo]
+	cpu       *collector[*handlers.CPUInfo]
+	disk      *collector[*handlers.DiskInfo]
+	network   *collector[*handlers.NetworkInfo]
+	processes *collector[*handlers.ProcessInfo]
+	load      *collector[*handlers.LoadInfo]
+	numa      *collector[[]handlers.NUMANodeInfo]
+	swap      *collector[*handlers.SwapInfo]
+	pressure  *collector[*handlers.PressureInfo]
+	fd        *collector[*handlers.FDInfo]
+	clock     *collector[*handlers.ClockInfo]
+	docker    *collector[[]handlers.ContainerStat]
+	dirs      *collector[[]handlers.DirInfo]
+	smart     *collector[[]handlers.DiskHealth]
+	// custom runs one collector per --custom-metric, in flag order
+	custom []*collector[handlers.CustomMetric]
+}
+
+// collectorInterval returns the configured cadence for a collector: its
+// --intervals entry, else --interval (a minute for directory walks and clock
+// checks, five for SMART queries). Custom metrics use the interval in their flag, else
+// the "custom" entry.
+func (s *Server) collectorInterval(name string) time.Duration {
+	cfg := s.getConfig()
+	if metric, ok := strings.CutPrefix(name, collectorCustom+":"); ok {
+		for _, command := range cfg.CustomMetrics {
+			if command.Name == metric && command.Interval > 0 {
+				return command.Interval
+			}
+		}
+		name = collectorCustom
+	}
+	if d, ok := cfg.Intervals[name]; ok {
+		return d
+	}
+	switch name {
+	case collectorDirs:
+		return defaultDirsInterval
+	case collectorSmart:
+		return defaultSmartInterval
+	case collectorClock:
+		return defaultClockInterval
+	}
+	return cfg.Interval
+}
+
+// newCollectors builds the collectors using the configured per-source
+// intervals. Collectors left out of --panels are nil and never run. Load,
+// NUMA, pressure, file descriptor and clock collectors are also nil when the
+// host lacks them, and the Docker and directory collectors unless enabled by
+// flags.
+func (s *Server) newCollectors() *collectors {
+	cfg := s.getConfig()
+	interval := s.collectorInterval
+
+	c := &collectors{}
+	if cfg.panelEnabled(collectorSystem) {
+		c.system = newCollector(collectorSystem, interval(collectorSystem), s.source.GetSystemInfo)
+	}
+	if cfg.panelEnabled(collectorCPU) {
+		c.cpu = newCollector(collectorCPU, interval(collectorCPU), s.source.GetCPUInfo)
+	}
+	if cfg.panelEnabled(collectorDisk) {
+		c.disk = newCollector(collectorDisk, interval(collectorDisk), (&mountWatch{}).watchMounts(s.source.GetDiskInfo))
+	}
+	if cfg.panelEnabled(collectorNetwork) {
+		c.network = newCollector(collectorNetwork, interval(collectorNetwork), func() (*handlers.NetworkInfo, error) {
+			return s.netTracker.GetNetworkInfo(s.getConfig().NetIfaces)
+		})
+	}
+	if cfg.panelEnabled(collectorProcesses) {
+		c.processes = newCollector(collectorProcesses, interval(collectorProcesses), func() (*handlers.ProcessInfo, error) {
+			cfg := s.getConfig()
+			return s.procTracker.GetProcessInfo(cfg.MaxProcesses, cfg.ProcessGrouping, cfg.ProcessSort, cfg.ProcessIO)
+		})
+	}
+	if s.platform.HasLoadAvg && cfg.panelEnabled(collectorLoad) {
+		c.load = newCollector(collectorLoad, interval(collectorLoad), handlers.GetLoadInfo)
+	}
+	if s.hasNUMA && cfg.panelEnabled(collectorNUMA) {
+		c.numa = newCollector(collectorNUMA, interval(collectorNUMA), handlers.GetNUMAInfo)
+	}
+	if cfg.panelEnabled(collectorSwap) {
+		c.swap = newCollector(collectorSwap, interval(collectorSwap), s.swapTracker.GetSwapInfo)
+	}
+	if s.hasPressure && cfg.panelEnabled(collectorPressure) {
+		c.pressure = newCollector(collectorPressure, interval(collectorPressure), handlers.GetPressureInfo)
+	}
+	if s.hasFD && cfg.panelEnabled(collectorFD) {
+		c.fd = newCollector(collectorFD, interval(collectorFD), handlers.GetFDInfo)
+	}
+	if s.hasClock && cfg.panelEnabled(collectorClock) {
+		c.clock = newCollector(collectorClock, interval(collectorClock), func() (*handlers.ClockInfo, error) {
+			return handlers.GetClockInfo(cfg.NTPServer, ntpTimeout)
+		})
+	}
+	if s.containerTracker != nil && cfg.panelEnabled(collectorDocker) {
+		c.docker = newCollector(collectorDocker, interval(collectorDocker), s.containerTracker.GetContainerStats)
+	}
+	if len(cfg.WatchDirs) > 0 && cfg.panelEnabled(collectorDirs) {
+		watchDirs := cfg.WatchDirs
+		c.dirs = newCollector(collectorDirs, interval(collectorDirs), func() ([]handlers.DirInfo, error) {
+			return handlers.GetDirSizes(watchDirs)
+		})
+	}
+
+	if s.hasSmartctl && cfg.panelEnabled(collectorSmart) {
+		c.smart = newCollector(collectorSmart, interval(collectorSmart), handlers.GetDiskHealth)
+	}
+
+	if cfg.panelEnabled(collectorCustom) {
+		for _, command := range cfg.CustomMetrics {
+			name := collectorCustom + ":" + command.Name
+			c.custom = append(c.custom, newCollector(name, interval(name), func() (handlers.CustomMetric, error) {
+				return handlers.RunCustomCommand(command, s.getConfig().CustomTimeout)
+			}))
+		}
+	}
+
+	if cfg.Concurrency > 0 {
+		slots := make(chan struct{}, cfg.Concurrency)
+		for _, r := range c.all() {
+			r.limit(slots)
+		}
+	}
+
+	return c
+}
+
+// runner is the part of a collector that doesn't depend on its value type
+type runner interface {
+	collectorName() string
+	start()
+	setInterval(time.Duration)
+	limit(slots chan struct{})
+	pauseWith(gate *idleGate)
+	collected() bool
+	denied() bool
+	refresh()
+	collectOnce() error
+}
+
+func (c *collector[T]) collectorName() string {
+	return c.name
+}
+
+// limit makes the collector share slots with the others; it must be called
+// before start
+func (c *collector[T]) limit(slots chan struct{}) {
+	c.slots = slots
+}
+
+// pauseWith holds the collector at gate while it is paused; it must be
+// called before start
+func (c *collector[T]) pauseWith(gate *idleGate) {
+	c.gate = gate
+}
+
+// collected reports whether the collector has a value yet
+func (c *collector[T]) collected() bool {
+	_, ok := c.latest()
+	return ok
+}
+
+// denied reports whether the last collection was refused for lack of
+// privileges
+func (c *collector[T]) denied() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refused
+}
+
+// all returns the enabled collectors
+func (c *collectors) all() []runner {
+	var runners []runner
+	add := func(r runner, enabled bool) {
+		if enabled {
+			runners = append(runners, r)
+		}
+	}
+	add(c.system, c.system != nil)
+	add(c.cpu, c.cpu != nil)
+	add(c.disk, c.disk != nil)
+	add(c.network, c.network != nil)
+	add(c.processes, c.processes != nil)
+	add(c.load, c.load != nil)
+	add(c.numa, c.numa != nil)
+	add(c.swap, c.swap != nil)
+	add(c.pressure, c.pressure != nil)
+	add(c.fd, c.fd != nil)
+	add(c.clock, c.clock != nil)
+	add(c.docker, c.docker != nil)
+	add(c.dirs, c.dirs != nil)
+	add(c.smart, c.smart != nil)
+	for _, custom := range c.custom {
+		add(custom, true)
+	}
+	return runners
+}
+
+// setIntervals updates every collector's cadence, for a configuration reload
+func (c *collectors) setIntervals(interval func(name string) time.Duration) {
+	for _, r := range c.all() {
+		r.setInterval(interval(r.collectorName()))
+	}
+}
+
+// collected reports whether every enabled collector has a value or has been
+// refused permission, so warm-up does not wait on a source it cannot read
+func (c *collectors) collected() bool {
+	for _, r := range c.all() {
+		if !r.collected() && !r.denied() {
+			return false
+		}
+	}
+	return true
+}
+
+// refresh collects every enabled collector now, in parallel, and waits for
+// them all
+func (c *collectors) refresh() {
+	var wg sync.WaitGroup
+	for _, r := range c.all() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.refresh()
+		}()
+	}
+	wg.Wait()
+}
+
+// deniedNames lists the collectors refused permission on their last run
+func (c *collectors) deniedNames() []string {
+	var names []string
+	for _, r := range c.all() {
+		if r.denied() {
+			names = append(names, r.collectorName())
+		}
+	}
+	return names
+}
+
+func (c *collectors) start() {
+	for _, r := range c.all() {
+		r.start()
+	}
+}
+
+// latestRequired loads a collector's value into dst, failing until it has
+// collected once. A disabled or denied collector leaves dst nil.
+func latestRequired[T any](c *collector[T], dst *T) error {
+	if c == nil || c.denied() {
+		return nil
+	}
+	value, ok := c.latest()
+	if !ok {
+		return fmt.Errorf("no %s data yet", c.name)
+	}
+	*dst = value
+	return nil
+}
+
+// latestOptional loads a collector's value into dst if it has one and is
+// not denied
+func latestOptional[T any](c *collector[T], dst *T) {
+	if c != nil && !c.denied() {
+		*dst, _ = c.latest()
+	}
+}
+
+// collectSnapshot merges the latest value of every collector into a snapshot
+// and folds its CPU usage into the moving average. Panels disabled with
+// --panels, and those the monitor lacks permission to read, are left nil.
+func (s *Server) collectSnapshot() (*metrics.Snapshot, error) {
+	snapshot, err := s.latestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.CPU != nil && len(snapshot.CPU.Percentages) > 0 {
+		snapshot.CPUAverage = s.cpuAverage.Add(snapshot.Sample().CPUPercent)
+	}
+	return snapshot, nil
+}
+
+// latestSnapshot merges the latest value of every collector into a snapshot
+// like collectSnapshot, leaving the moving average as it is
+func (s *Server) latestSnapshot() (*metrics.Snapshot, error) {
+	c := s.collectors
+	snapshot := &metrics.Snapshot{Time: time.Now()}
+
+	if err := errors.Join(
+		latestRequired(c.system, &snapshot.System),
+		latestRequired(c.cpu, &snapshot.CPU),
+		latestRequired(c.disk, &snapshot.Disk),
+		latestRequired(c.network, &snapshot.Network),
+		latestRequired(c.processes, &snapshot.Processes),
+	); err != nil {
+		return nil, err
+	}
+	latestOptional(c.load, &snapshot.Load)
+	latestOptional(c.numa, &snapshot.NUMA)
+	latestOptional(c.swap, &snapshot.Swap)
+	latestOptional(c.pressure, &snapshot.Pressure)
+	latestOptional(c.fd, &snapshot.FD)
+	latestOptional(c.clock, &snapshot.Clock)
+	latestOptional(c.docker, &snapshot.Containers)
+	latestOptional(c.dirs, &snapshot.Dirs)
+	latestOptional(c.smart, &snapshot.DiskHealth)
+	for _, custom := range c.custom {
+		if metric, ok := custom.latest(); ok {
+			snapshot.Custom = append(snapshot.Custom, metric)
+		}
+	}
+	snapshot.Denied = c.deniedNames()
+	snapshot.CPUAverage = s.cpuAverage.Value()
+
+	return snapshot, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"system-monitor/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// compactLine renders the headline metrics as one short plain-text line for
+// status bars and small displays, e.g. "CPU 23% MEM 61% DISK 48%". Panels
+// disabled with --panels are left out.
+func compactLine(snapshot *metrics.Snapshot) string {
+	sample := snapshot.Sample()
+	var fields []string
+	if snapshot.CPU != nil {
+		fields = append(fields, fmt.Sprintf("CPU %.0f%%", sample.CPUPercent))
+	}
+	if snapshot.System != nil {
+		fields = append(fields, fmt.Sprintf("MEM %.0f%%", sample.MemUsedPercent))
+	}
+	if snapshot.Disk != nil {
+		fields = append(fields, fmt.Sprintf("DISK %.0f%%", sample.DiskUsedPercent))
+	}
+	return strings.Join(fields, " ")
+}
+
+// compactHandler returns the latest compact line, for clients that poll
+// rather than hold a stream open
+func (s *Server) compactHandler(c *fiber.Ctx) error {
+	s.wakeForPull(c)
+	snapshot := s.getLatest()
+	if snapshot == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "no metrics collected yet")
+	}
+	return c.SendString(compactLine(snapshot) + "\n")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+
+	"system-monitor/console"
+
+	fastws "github.com/fasthttp/websocket"
+)
+
+// deflateExtension is the websocket extension negotiated by --ws-compression
+const deflateExtension = "permessage-deflate"
+
+// streamMessage is one message queued for a subscriber. Prepared is set
+// for websocket subscribers that negotiated compression: it compresses the
+// frame the first time one of them writes it and shares the result with
+// the rest. Everyone else is sent data as is.
+type streamMessage struct {
+	data     []byte
+	prepared *fastws.PreparedMessage
+}
+
+// offersDeflate reports whether a Sec-WebSocket-Extensions offer includes
+// permessage-deflate, which the upgrader then accepts
+func offersDeflate(extensions string) bool {
+	for _, offer := range strings.Split(extensions, ",") {
+		name, _, _ := strings.Cut(offer, ";")
+		if strings.EqualFold(strings.TrimSpace(name), deflateExtension) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareFrames wraps each frame for the compressing subscribers. It
+// returns nil when --ws-compression is off; a frame that cannot be
+// prepared is left out and sent uncompressed.
+func (s *Server) prepareFrames(frames map[string][]byte) map[string]*fastws.PreparedMessage {
+	if !s.getConfig().WSCompression {
+		return nil
+	}
+	prepared := make(map[string]*fastws.PreparedMessage, len(frames))
+	for format, frame := range frames {
+		pm, err := fastws.NewPreparedMessage(fastws.TextMessage, frame)
+		if err != nil {
+			console.Errorf("Error preparing %s frame for compression: %v", format, err)
+			continue
+		}
+		prepared[format] = pm
+	}
+	return prepared
+}
+
+// messageFor picks the subscriber's variant of a published frame
+func messageFor(subscriber *Subscriber, frames map[string][]byte, prepared map[string]*fastws.PreparedMessage) streamMessage {
+	msg := streamMessage{data: frames[subscriber.format]}
+	if subscriber.compressed {
+		msg.prepared = prepared[subscriber.format]
+	}
+	return msg
+}
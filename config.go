@@ -0,0 +1,646 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"system-monitor/format"
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+	"system-monitor/templates"
+
+	// Embed the zone database so --timezone works on hosts without one,
+	// such as Windows and the Alpine image
+	_ "time/tzdata"
+)
+
+// Network panel views
+const (
+	netViewTotal      = "total"
+	netViewInterfaces = "interfaces"
+	netViewBoth       = "both"
+)
+
+// Log formats accepted by --log-format
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// Config holds the runtime configuration parsed from command-line flags
+type Config struct {
+	Interval        time.Duration
+	PublishInterval time.Duration
+	AlignTicks      bool
+	IdlePause       bool
+	Intervals       map[string]time.Duration
+	Concurrency     int
+	WarmupTicks     int
+	Panels          []string
+	NetView         string
+	NetIfaces       handlers.InterfaceFilter
+	HistorySize     int
+	DBPath          string
+	DBRetentionDays int
+	MaxProcesses    int
+	ProcessGrouping string
+	ProcessSort     string
+	ProcessIO       bool
+	ZombieWarning   int
+	MaxFrameBytes   int
+	FrameTemplate   *template.Template
+	JSONPrecision   int
+	LoadPrecision   int
+	CORSOrigins     []string
+	Redact          bool
+	DiskTrendTicks  int
+	CPUSmoothing    float64
+	RollingWindow   time.Duration
+	CPUSample       time.Duration
+	CPULayout       string
+	Docker          bool
+	SMART           bool
+	CustomMetrics   []handlers.CustomCommand
+	CustomTimeout   time.Duration
+	NTPServer       string
+	ClockSkewWarn   time.Duration
+	Location        *time.Location
+	Locale          format.Locale
+	Units           format.Units
+	Health          metrics.HealthThresholds
+	WSWriteTimeout  time.Duration
+	WSCompression   bool
+	SubsWarning     int
+	WatchDirs       []string
+	DiskPath        string
+	SnapshotFile    string
+	UnixSocket      string
+	LogFormat       string
+	LogLevel        slog.Level
+	AccessLog       bool
+	AccessLogSkip   []string
+	GRPCPort        int
+	PushGateway     string
+	NDJSONFile      string
+	Replay          string
+	ReplaySpeed     float64
+	ReplayLoop      bool
+	HostnameLabel   string
+	HostLabel       string
+	Influx          metrics.InfluxConfig
+	SNMPTarget      string
+	SNMPCommunity   string
+	SNMPTimeout     time.Duration
+	FleetTargets    []FleetTarget
+	FleetInterval   time.Duration
+	AdminUser       string
+	AdminPassword   string
+	ResolveDNS      bool
+	Protect         bool
+	ShareSecret     string
+	ShareTTL        time.Duration
+	Headless        bool
+	TUI             bool
+	NoColor         bool
+	NoEmoji         bool
+	Benchmark       bool
+	BenchmarkRuns   int
+	Check           bool
+
+	// flagValues holds the effective value of every flag, so a reload can
+	// report which ones changed
+	flagValues map[string]string
+}
+
+func parseConfig() (*Config, error) {
+	return loadConfig(os.Args[1:], flag.ExitOnError)
+}
+
+// loadConfig parses args, filling in any flag they leave unset from the
+// --config file. Reloads call it again with the original arguments.
+func loadConfig(args []string, errorHandling flag.ErrorHandling) (*Config, error) {
+	cfg := &Config{}
+	fs := flag.NewFlagSet(os.Args[0], errorHandling)
+
+	configFile := fs.String("config", "", "file of flag=value lines applied under the command line; re-read on SIGHUP")
+
+	fs.DurationVar(&cfg.Interval, "interval", 2*time.Second, "how often frames are published")
+	fs.DurationVar(&cfg.PublishInterval, "publish-interval", 0, "minimum time between frames sent to the dashboard, e.g. 5s for wall displays; metrics are still collected every --interval (0 sends every --interval)")
+	fs.BoolVar(&cfg.AlignTicks, "align-ticks", false, "publish at whole multiples of --interval on the wall clock, e.g. :00, :02, :04 for 2s, so samples from several hosts line up")
+	fs.BoolVar(&cfg.IdlePause, "idle-pause", false, "stop collecting while no dashboard or stream is connected, resuming on the next connect (ignored while exports or --db record every snapshot)")
+	intervals := fs.String("intervals", "", "per-collector intervals, e.g. cpu=1s,disk=30s,network=2s (defaults to --interval)")
+	panels := fs.String("panels", "", "comma-separated panels to collect and show, e.g. system,cpu,disk (default: all that apply; names as in --intervals)")
+	fs.IntVar(&cfg.Concurrency, "collector-concurrency", 0, "maximum number of collectors running at once; others wait their turn (0 for no limit)")
+	fs.IntVar(&cfg.WarmupTicks, "warmup-ticks", 1, "complete snapshots to discard at startup before publishing, so rates have a previous reading")
+	fs.StringVar(&cfg.NetView, "net-view", netViewBoth, "network panel view: total, interfaces or both")
+	var netIfaces stringList
+	fs.Var(&netIfaces, "net-iface", "glob pattern of network interfaces to show, e.g. eth*, or to hide when prefixed with !, e.g. !veth* (repeatable; all interfaces by default)")
+	fs.IntVar(&cfg.HistorySize, "history-size", 1800, "number of samples kept in the in-memory history")
+	fs.StringVar(&cfg.DBPath, "db-path", "", "SQLite file to persist history to (disabled when empty)")
+	fs.IntVar(&cfg.DBRetentionDays, "db-retention-days", 7, "days of history kept in the SQLite database")
+	fs.IntVar(&cfg.MaxProcesses, "max-processes", 25, "maximum number of rows in the process table (0 for no limit)")
+	fs.StringVar(&cfg.ProcessGrouping, "process-grouping", handlers.GroupNone, "aggregate the process table into collapsible groups: none, parent (by parent PID) or name")
+	fs.StringVar(&cfg.ProcessSort, "process-sort", handlers.ProcessSortCPU, "process table order: cpu, or io for the read and write rate (which implies --process-io)")
+	fs.BoolVar(&cfg.ProcessIO, "process-io", false, "add read and write columns to the process table; other users' processes need elevated privileges on Linux and macOS")
+	fs.IntVar(&cfg.ZombieWarning, "zombie-warning", 5, "zombie process count at which the system panel warns; read during process collection")
+	fs.IntVar(&cfg.MaxFrameBytes, "max-frame-bytes", 1<<20, "maximum size of a rendered frame in bytes (0 for no limit)")
+	frameTemplate := fs.String("frame-template", "", "html/template file laying out each live frame from .Panels and .Panel \"id\", instead of swapping every panel into its container")
+	fs.IntVar(&cfg.LoadPrecision, "load-precision", 2, "decimal places of the load averages on the dashboard")
+	fs.IntVar(&cfg.JSONPrecision, "json-precision", 2, "decimal places of percentages in JSON responses and frames (-1 for full precision)")
+	fs.DurationVar(&cfg.RollingWindow, "rolling-window", time.Minute, "window for the peak and average CPU and memory usage shown under the CPU gauges, taken from the in-memory history (0 hides them)")
+	fs.Float64Var(&cfg.CPUSmoothing, "cpu-smoothing", 0.2, "smoothing factor in (0, 1] for the averaged CPU usage; lower values react more slowly")
+	fs.DurationVar(&cfg.CPUSample, "cpu-sample-interval", 0, "measure CPU usage over this fixed window on a dedicated goroutine, e.g. 1s, instead of over the time since the previous collection (0 to disable)")
+	fs.StringVar(&cfg.CPULayout, "cpu-layout", templates.CPULayoutAuto, "CPU core layout: bars, heatmap, or auto for a heatmap above 32 cores")
+	fs.IntVar(&cfg.DiskTrendTicks, "disk-trend-ticks", 30, "publisher ticks between the readings compared for the disk usage trend arrows")
+	fs.BoolVar(&cfg.Docker, "docker", false, "collect per-container stats from the Docker daemon")
+	fs.BoolVar(&cfg.SMART, "smart", false, "report disk SMART health with smartctl, every five minutes unless set in --intervals (usually needs root)")
+	timezone := fs.String("timezone", "Local", "IANA time zone for displayed timestamps, e.g. Europe/Berlin (defaults to the server's zone)")
+	units := fs.String("units", "binary", "byte size convention: binary (KiB, MiB: multiples of 1024) or decimal (kB, MB: multiples of 1000)")
+	locale := fs.String("locale", "en", "digit grouping for displayed numbers: en (16,384.5), de (16.384,5), fr (16 384,5), ch (16'384.5) or none")
+	healthThresholds := fs.String("health-thresholds", "", "health summary and gauge color thresholds as warning:critical percentages, e.g. cpu=75:90,memory=80:95,disk=80:90")
+	fs.BoolVar(&cfg.WSCompression, "ws-compression", false, "offer permessage-deflate to websocket clients; each frame is compressed once and shared by every client that accepts it")
+	fs.IntVar(&cfg.SubsWarning, "subscriber-warning", 1000, "live stream clients above which a warning is logged, checked every minute (0 for no bound)")
+	fs.DurationVar(&cfg.WSWriteTimeout, "ws-write-timeout", 10*time.Second, "drop websocket clients whose writes block for longer than this (0 to wait indefinitely)")
+	fs.BoolVar(&cfg.Headless, "headless", false, "print one summary line per --interval to stdout instead of serving the dashboard")
+	fs.BoolVar(&cfg.TUI, "tui", false, "draw live panels in the terminal, quitting on q or Ctrl+C, instead of serving the dashboard")
+	fs.BoolVar(&cfg.NoColor, "no-color", false, "disable colors in --headless and --tui output (headless colors are also off when stdout is not a terminal)")
+	fs.BoolVar(&cfg.NoEmoji, "no-emoji", false, "prefix log lines with ASCII tags such as [ok] instead of emoji (the default on the legacy Windows console)")
+	fs.BoolVar(&cfg.Benchmark, "benchmark", false, "time each collector, print min/avg/max latency and exit without serving")
+	fs.IntVar(&cfg.BenchmarkRuns, "benchmark-runs", 10, "collections per collector in --benchmark mode")
+	fs.BoolVar(&cfg.Check, "check", false, "collect once, print a summary and exit 0 when healthy, 1 on a warning, 2 on a critical threshold or 3 when collection fails, without serving")
+	fs.StringVar(&cfg.DiskPath, "disk-path", "", "filesystem the disk panel and health rating report (default: the one the OS runs from, or the largest mount when that is a container layer or RAM disk)")
+	fs.Var((*stringList)(&cfg.WatchDirs), "watch-dir", "directory whose total size is tracked (repeatable; refreshed every minute unless set in --intervals)")
+	fs.StringVar(&cfg.HostnameLabel, "hostname-label", "", "hostname shown in the dashboard and API and used to label exported metrics (defaults to the real hostname)")
+	fs.IntVar(&cfg.GRPCPort, "grpc-port", 0, "also serve the metrics as a gRPC stream on this TCP port, see monitorpb/monitor.proto (disabled when 0)")
+	level := fs.String("log-level", "info", "least severe log records printed: debug, info, warn or error")
+	fs.StringVar(&cfg.LogFormat, "log-format", logFormatText, "log output: text status lines, or json for one log/slog JSON record per line")
+	fs.BoolVar(&cfg.AccessLog, "access-log", true, "log one record per HTTP request, with method, path, status, latency, bytes and client address")
+	accessLogSkip := fs.String("access-log-skip", "", "comma-separated path prefixes left out of the access log, e.g. /readyz,/metrics for probes and scrapes")
+	fs.StringVar(&cfg.UnixSocket, "unix-socket", "", "listen on this Unix domain socket instead of TCP port 6080, e.g. for a local reverse proxy")
+	fs.StringVar(&cfg.SnapshotFile, "snapshot-file", "", "write the fully rendered dashboard as static HTML to this file every tick")
+	fs.StringVar(&cfg.Influx.URL, "influx-url", "", "push metrics every tick to this InfluxDB server, e.g. http://localhost:8086 (disabled when empty)")
+	fs.StringVar(&cfg.Influx.Bucket, "influx-bucket", "", "InfluxDB bucket, or database/retention-policy for InfluxDB 1.8")
+	fs.StringVar(&cfg.Influx.Org, "influx-org", "", "InfluxDB organization")
+	fs.StringVar(&cfg.Influx.Token, "influx-token", os.Getenv("INFLUX_TOKEN"), "InfluxDB API token (default $INFLUX_TOKEN)")
+	fs.StringVar(&cfg.PushGateway, "push-gateway", "", "push headline metrics every tick to this Prometheus Pushgateway, e.g. http://localhost:9091 (disabled when empty)")
+	fs.StringVar(&cfg.NDJSONFile, "ndjson-file", "", "append every collected snapshot to this file as newline-delimited JSON (disabled when empty)")
+	fs.StringVar(&cfg.Replay, "replay", "", "serve the snapshots recorded by --ndjson-file to this file instead of collecting live metrics")
+	fs.Float64Var(&cfg.ReplaySpeed, "replay-speed", 1, "playback speed of --replay, e.g. 10 for ten times faster")
+	fs.BoolVar(&cfg.ReplayLoop, "replay-loop", false, "start --replay over when the recording ends")
+	influxTags := fs.String("influx-tags", "", "extra tags for every InfluxDB point, e.g. dc=eu1,role=web (host defaults to the hostname)")
+	fs.StringVar(&cfg.SNMPTarget, "snmp-target", "", "poll system, CPU and disk metrics from this host[:port] over SNMP v2c instead of the local machine")
+	fs.StringVar(&cfg.SNMPCommunity, "snmp-community", "public", "SNMP community string for --snmp-target")
+	fs.DurationVar(&cfg.SNMPTimeout, "snmp-timeout", 2*time.Second, "timeout for each SNMP request")
+	var customMetrics stringList
+	fs.Var(&customMetrics, "custom-metric", "shell command whose numeric output is shown in the custom metrics panel, as name[:interval[:unit]]=command, e.g. queue:30s:jobs=redis-cli llen jobs (repeatable)")
+	fs.DurationVar(&cfg.CustomTimeout, "custom-timeout", 10*time.Second, "time a --custom-metric command may run before it is killed")
+	fs.StringVar(&cfg.NTPServer, "ntp-server", "", "NTP server, as host[:port], the clock offset is measured against (default the kernel's own estimate, Linux only)")
+	fs.DurationVar(&cfg.ClockSkewWarn, "clock-skew-warning", 100*time.Millisecond, "clock offset from which the clock panel shows a warning")
+	var fleetTargets stringList
+	fs.Var(&fleetTargets, "fleet-target", "another monitor polled for the /fleet overview, as name=http://host:6080 (repeatable)")
+	fs.DurationVar(&cfg.FleetInterval, "fleet-interval", 10*time.Second, "how often each --fleet-target is polled")
+	fs.StringVar(&cfg.AdminUser, "admin-user", "admin", "user name for the admin endpoints")
+	fs.StringVar(&cfg.AdminPassword, "admin-password", os.Getenv("MONITOR_ADMIN_PASSWORD"), "password protecting the admin endpoints with basic auth (default $MONITOR_ADMIN_PASSWORD)")
+	fs.BoolVar(&cfg.ResolveDNS, "resolve-subscribers", false, "annotate /api/subscribers with the reverse DNS name of each client, cached for 10 minutes")
+	fs.BoolVar(&cfg.Protect, "protect-dashboard", false, "require the admin credentials for the dashboard and metrics endpoints too")
+	fs.StringVar(&cfg.ShareSecret, "share-secret", os.Getenv("MONITOR_SHARE_SECRET"), "secret signing read-only share links from POST /api/share under --protect-dashboard (default $MONITOR_SHARE_SECRET)")
+	fs.DurationVar(&cfg.ShareTTL, "share-ttl", 24*time.Hour, "how long a share link stays valid unless the request sets ?ttl=")
+	fs.BoolVar(&cfg.Redact, "redact", false, "mask hostname and platform in the dashboard and API")
+	corsOrigins := fs.String("cors-origins", "", "comma-separated origins allowed to call /api/* cross-origin (same-origin only when empty)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if *configFile != "" {
+		if err := applyConfigFile(fs, *configFile); err != nil {
+			return nil, fmt.Errorf("invalid --config %s: %w", *configFile, err)
+		}
+	}
+	cfg.flagValues = make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) {
+		cfg.flagValues[f.Name] = f.Value.String()
+	})
+
+	cfg.CORSOrigins = splitList(*corsOrigins)
+
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("invalid --interval %s: must be positive", cfg.Interval)
+	}
+
+	if cfg.PublishInterval < 0 {
+		return nil, fmt.Errorf("invalid --publish-interval %s: must not be negative", cfg.PublishInterval)
+	}
+
+	var err error
+	if cfg.Intervals, err = parseIntervals(*intervals); err != nil {
+		return nil, fmt.Errorf("invalid --intervals: %w", err)
+	}
+
+	if err := cfg.LogLevel.UnmarshalText([]byte(*level)); err != nil {
+		return nil, fmt.Errorf("invalid --log-level %q: must be debug, info, warn or error", *level)
+	}
+	switch cfg.LogFormat {
+	case logFormatText, logFormatJSON:
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q: must be text or json", cfg.LogFormat)
+	}
+	cfg.AccessLogSkip = splitList(*accessLogSkip)
+	for _, prefix := range cfg.AccessLogSkip {
+		if !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("invalid --access-log-skip %q: paths must start with /", prefix)
+		}
+	}
+
+	cfg.Panels = splitList(*panels)
+	for _, name := range cfg.Panels {
+		if !slices.Contains(collectorNames, name) {
+			return nil, fmt.Errorf("invalid --panels: unknown panel %q (known: %s)", name, strings.Join(collectorNames, ", "))
+		}
+	}
+
+	if cfg.Concurrency < 0 {
+		return nil, fmt.Errorf("invalid --collector-concurrency %d: must not be negative", cfg.Concurrency)
+	}
+
+	if cfg.WarmupTicks < 0 {
+		return nil, fmt.Errorf("invalid --warmup-ticks %d: must not be negative", cfg.WarmupTicks)
+	}
+
+	switch cfg.NetView {
+	case netViewTotal, netViewInterfaces, netViewBoth:
+	default:
+		return nil, fmt.Errorf("invalid --net-view %q: must be total, interfaces or both", cfg.NetView)
+	}
+
+	if cfg.NetIfaces, err = handlers.ParseInterfaceFilter(netIfaces); err != nil {
+		return nil, fmt.Errorf("invalid --net-iface: %w", err)
+	}
+
+	if cfg.HistorySize < 1 {
+		return nil, fmt.Errorf("invalid --history-size %d: must be at least 1", cfg.HistorySize)
+	}
+
+	if cfg.DBRetentionDays < 1 {
+		return nil, fmt.Errorf("invalid --db-retention-days %d: must be at least 1", cfg.DBRetentionDays)
+	}
+
+	if cfg.MaxProcesses < 0 {
+		return nil, fmt.Errorf("invalid --max-processes %d: must not be negative", cfg.MaxProcesses)
+	}
+
+	switch cfg.ProcessGrouping {
+	case handlers.GroupNone, handlers.GroupParent, handlers.GroupName:
+	default:
+		return nil, fmt.Errorf("invalid --process-grouping %q: must be none, parent or name", cfg.ProcessGrouping)
+	}
+	switch cfg.ProcessSort {
+	case handlers.ProcessSortCPU, handlers.ProcessSortIO:
+	default:
+		return nil, fmt.Errorf("invalid --process-sort %q: must be cpu or io", cfg.ProcessSort)
+	}
+
+	if cfg.ZombieWarning < 1 {
+		return nil, fmt.Errorf("invalid --zombie-warning %d: must be at least 1", cfg.ZombieWarning)
+	}
+
+	if cfg.MaxFrameBytes < 0 {
+		return nil, fmt.Errorf("invalid --max-frame-bytes %d: must not be negative", cfg.MaxFrameBytes)
+	}
+	if *frameTemplate != "" {
+		if cfg.FrameTemplate, err = templates.ParseFrameTemplate(*frameTemplate); err != nil {
+			return nil, fmt.Errorf("invalid --frame-template: %w", err)
+		}
+	}
+
+	if cfg.Protect && cfg.AdminPassword == "" {
+		return nil, fmt.Errorf("invalid --protect-dashboard: requires --admin-password")
+	}
+
+	if cfg.ShareTTL <= 0 {
+		return nil, fmt.Errorf("invalid --share-ttl %s: must be positive", cfg.ShareTTL)
+	}
+
+	if cfg.LoadPrecision < 0 {
+		return nil, fmt.Errorf("invalid --load-precision %d: must not be negative", cfg.LoadPrecision)
+	}
+	if cfg.JSONPrecision < -1 {
+		return nil, fmt.Errorf("invalid --json-precision %d: must be -1 or more", cfg.JSONPrecision)
+	}
+
+	if cfg.Health, err = parseHealthThresholds(*healthThresholds); err != nil {
+		return nil, fmt.Errorf("invalid --health-thresholds: %w", err)
+	}
+
+	if cfg.Location, err = time.LoadLocation(*timezone); err != nil {
+		return nil, fmt.Errorf("invalid --timezone %q: %w", *timezone, err)
+	}
+
+	if cfg.Locale, err = format.ParseLocale(*locale); err != nil {
+		return nil, fmt.Errorf("invalid --locale %q: %w", *locale, err)
+	}
+
+	if cfg.Units, err = format.ParseUnits(*units); err != nil {
+		return nil, fmt.Errorf("invalid --units %q: %w", *units, err)
+	}
+
+	if cfg.CustomMetrics, err = parseCustomMetrics(customMetrics); err != nil {
+		return nil, fmt.Errorf("invalid --custom-metric: %w", err)
+	}
+	if cfg.CustomTimeout <= 0 {
+		return nil, fmt.Errorf("invalid --custom-timeout %s: must be positive", cfg.CustomTimeout)
+	}
+	if cfg.DiskPath != "" {
+		if _, err := os.Stat(cfg.DiskPath); err != nil {
+			return nil, fmt.Errorf("invalid --disk-path: %w", err)
+		}
+	}
+	if cfg.ClockSkewWarn <= 0 {
+		return nil, fmt.Errorf("invalid --clock-skew-warning %s: must be positive", cfg.ClockSkewWarn)
+	}
+
+	if cfg.FleetTargets, err = parseFleetTargets(fleetTargets); err != nil {
+		return nil, fmt.Errorf("invalid --fleet-target: %w", err)
+	}
+	if cfg.FleetInterval <= 0 {
+		return nil, fmt.Errorf("invalid --fleet-interval %s: must be positive", cfg.FleetInterval)
+	}
+
+	if cfg.BenchmarkRuns < 1 {
+		return nil, fmt.Errorf("invalid --benchmark-runs %d: must be at least 1", cfg.BenchmarkRuns)
+	}
+
+	if cfg.Influx.Tags, err = parseTags(*influxTags); err != nil {
+		return nil, fmt.Errorf("invalid --influx-tags: %w", err)
+	}
+	// Exported metrics are labelled with the override when set, otherwise
+	// the OS hostname
+	cfg.HostLabel = cfg.HostnameLabel
+	if cfg.HostLabel == "" {
+		cfg.HostLabel, _ = os.Hostname()
+	}
+	if _, ok := cfg.Influx.Tags["host"]; !ok && cfg.HostLabel != "" {
+		cfg.Influx.Tags["host"] = cfg.HostLabel
+	}
+	if cfg.Influx.URL != "" && cfg.Influx.Bucket == "" {
+		return nil, fmt.Errorf("invalid --influx-bucket: required with --influx-url")
+	}
+
+	if cfg.SNMPTimeout <= 0 {
+		return nil, fmt.Errorf("invalid --snmp-timeout %s: must be positive", cfg.SNMPTimeout)
+	}
+
+	if cfg.WSWriteTimeout < 0 {
+		return nil, fmt.Errorf("invalid --ws-write-timeout %s: must not be negative", cfg.WSWriteTimeout)
+	}
+	if cfg.ReplaySpeed <= 0 {
+		return nil, fmt.Errorf("invalid --replay-speed %g: must be positive", cfg.ReplaySpeed)
+	}
+	if cfg.Replay != "" && cfg.NDJSONFile == cfg.Replay {
+		return nil, fmt.Errorf("invalid --ndjson-file: cannot record to the --replay file")
+	}
+	if cfg.GRPCPort < 0 || cfg.GRPCPort > 65535 {
+		return nil, fmt.Errorf("invalid --grpc-port %d: must be between 0 and 65535", cfg.GRPCPort)
+	}
+	if cfg.SubsWarning < 0 {
+		return nil, fmt.Errorf("invalid --subscriber-warning %d: must not be negative", cfg.SubsWarning)
+	}
+
+	if cfg.RollingWindow < 0 {
+		return nil, fmt.Errorf("invalid --rolling-window %s: must not be negative", cfg.RollingWindow)
+	}
+	if cfg.CPUSmoothing <= 0 || cfg.CPUSmoothing > 1 {
+		return nil, fmt.Errorf("invalid --cpu-smoothing %g: must be greater than 0 and at most 1", cfg.CPUSmoothing)
+	}
+	if cfg.CPUSample < 0 {
+		return nil, fmt.Errorf("invalid --cpu-sample-interval %s: must not be negative", cfg.CPUSample)
+	}
+	switch cfg.CPULayout {
+	case templates.CPULayoutAuto, templates.CPULayoutBars, templates.CPULayoutHeatmap:
+	default:
+		return nil, fmt.Errorf("invalid --cpu-layout %q: must be auto, bars or heatmap", cfg.CPULayout)
+	}
+	if cfg.DiskTrendTicks < 1 {
+		return nil, fmt.Errorf("invalid --disk-trend-ticks %d: must be at least 1", cfg.DiskTrendTicks)
+	}
+
+	return cfg, nil
+}
+
+// panelEnabled reports whether --panels includes the named panel; every
+// panel is enabled when the flag is empty
+func (c *Config) panelEnabled(name string) bool {
+	return len(c.Panels) == 0 || slices.Contains(c.Panels, name)
+}
+
+// applyConfigFile sets every flag named in the file that was not given on
+// the command line. Lines are name=value, with blank lines and lines
+// starting with # ignored; repeatable flags may appear more than once.
+func applyConfigFile(fs *flag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimLeft(strings.TrimSpace(name), "-")
+		if !found || name == "" {
+			return fmt.Errorf("line %d: %q is not of the form name=value", i+1, line)
+		}
+		if name == "config" {
+			return fmt.Errorf("line %d: config files cannot include another", i+1)
+		}
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("line %d: unknown flag %q", i+1, name)
+		}
+		if explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("line %d: %s: %w", i+1, name, err)
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseIntervals parses a comma-separated list of collector=duration pairs
+func parseIntervals(v string) (map[string]time.Duration, error) {
+	intervals := make(map[string]time.Duration)
+	for _, item := range splitList(v) {
+		name, value, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("%q is not of the form collector=duration", item)
+		}
+		name = strings.TrimSpace(name)
+		if !slices.Contains(collectorNames, name) {
+			return nil, fmt.Errorf("unknown collector %q (known: %s)", name, strings.Join(collectorNames, ", "))
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%s: %q is not a positive duration", name, value)
+		}
+		intervals[name] = d
+	}
+	return intervals, nil
+}
+
+// parseHealthThresholds overrides the default thresholds with entries of the
+// form metric=warning:critical
+func parseHealthThresholds(v string) (metrics.HealthThresholds, error) {
+	thresholds := metrics.DefaultHealthThresholds
+	targets := map[string]*metrics.Threshold{
+		"cpu":    &thresholds.CPU,
+		"memory": &thresholds.Memory,
+		"disk":   &thresholds.Disk,
+	}
+
+	for _, item := range splitList(v) {
+		name, value, found := strings.Cut(item, "=")
+		if !found {
+			return thresholds, fmt.Errorf("%q is not of the form metric=warning:critical", item)
+		}
+		name = strings.TrimSpace(name)
+		target, ok := targets[name]
+		if !ok {
+			return thresholds, fmt.Errorf("unknown metric %q (known: cpu, memory, disk)", name)
+		}
+
+		warnValue, critValue, found := strings.Cut(value, ":")
+		if !found {
+			return thresholds, fmt.Errorf("%s: %q is not of the form warning:critical", name, value)
+		}
+		warning, err := strconv.ParseFloat(strings.TrimSpace(warnValue), 64)
+		if err != nil {
+			return thresholds, fmt.Errorf("%s: %q is not a number", name, warnValue)
+		}
+		critical, err := strconv.ParseFloat(strings.TrimSpace(critValue), 64)
+		if err != nil {
+			return thresholds, fmt.Errorf("%s: %q is not a number", name, critValue)
+		}
+		if warning > critical {
+			return thresholds, fmt.Errorf("%s: warning %g is above critical %g", name, warning, critical)
+		}
+		*target = metrics.Threshold{Warning: warning, Critical: critical}
+	}
+	return thresholds, nil
+}
+
+// parseTags parses comma-separated key=value pairs
+func parseTags(v string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, item := range splitList(v) {
+		key, value, found := strings.Cut(item, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" {
+			return nil, fmt.Errorf("%q is not of the form key=value", item)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+// FleetTarget is another monitor instance shown in the fleet overview
+type FleetTarget struct {
+	Name string
+	URL  string
+}
+
+// parseFleetTargets parses name=url items, requiring unique names and
+// http(s) URLs. A trailing slash is dropped from the URL.
+func parseFleetTargets(items []string) ([]FleetTarget, error) {
+	var targets []FleetTarget
+	seen := make(map[string]bool)
+	for _, item := range items {
+		name, rawURL, found := strings.Cut(item, "=")
+		name, rawURL = strings.TrimSpace(name), strings.TrimSpace(rawURL)
+		if !found || name == "" {
+			return nil, fmt.Errorf("%q is not of the form name=url", item)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate name %q", name)
+		}
+		seen[name] = true
+
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%q is not an http(s) URL", rawURL)
+		}
+		targets = append(targets, FleetTarget{Name: name, URL: strings.TrimSuffix(rawURL, "/")})
+	}
+	return targets, nil
+}
+
+// parseCustomMetrics parses name[:interval[:unit]]=command items, requiring
+// unique names. The command is everything after the first "=".
+func parseCustomMetrics(items []string) ([]handlers.CustomCommand, error) {
+	var commands []handlers.CustomCommand
+	seen := make(map[string]bool)
+	for _, item := range items {
+		spec, command, found := strings.Cut(item, "=")
+		command = strings.TrimSpace(command)
+		if !found || command == "" {
+			return nil, fmt.Errorf("%q is not of the form name[:interval[:unit]]=command", item)
+		}
+		parts := strings.SplitN(spec, ":", 3)
+		c := handlers.CustomCommand{Name: strings.TrimSpace(parts[0]), Command: command}
+		if c.Name == "" {
+			return nil, fmt.Errorf("%q has no name", item)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("duplicate name %q", c.Name)
+		}
+		seen[c.Name] = true
+
+		if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+			d, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("%q: interval %q is not a positive duration", c.Name, parts[1])
+			}
+			c.Interval = d
+		}
+		if len(parts) > 2 {
+			c.Unit = strings.TrimSpace(parts[2])
+		}
+		commands = append(commands, c)
+	}
+	return commands, nil
+}
+
+// stringList is a repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
@@ -0,0 +1,111 @@
+// Package console is the front of the monitor's log. Status lines go to a
+// shared log/slog logger, and are printed prefixed with an emoji icon, or
+// with an ASCII tag on consoles that cannot render emoji and under
+// --no-emoji.
+package console
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+)
+
+// Icon prefixes a status line. The emoji carries its own padding, as
+// those drawn from a variation selector render a column narrower.
+type Icon struct {
+	emoji string
+	ascii string
+	level slog.Level
+}
+
+var (
+	Start   = Icon{"🚀", "[start]", slog.LevelInfo}
+	Stack   = Icon{"📊", "[info]", slog.LevelInfo}
+	Version = Icon{"🏷️ ", "[info]", slog.LevelInfo}
+	Store   = Icon{"💾", "[db]", slog.LevelInfo}
+	Fleet   = Icon{"🛰️ ", "[fleet]", slog.LevelInfo}
+	Remote  = Icon{"📡", "[snmp]", slog.LevelInfo}
+	Timer   = Icon{"⏱️ ", "[time]", slog.LevelInfo}
+	Export  = Icon{"📈", "[export]", slog.LevelInfo}
+	Ready   = Icon{"✅", "[ok]", slog.LevelInfo}
+	Reload  = Icon{"🔄", "[reload]", slog.LevelInfo}
+	Quiet   = Icon{"🔕", "[quiet]", slog.LevelInfo}
+	Resume  = Icon{"🔔", "[quiet]", slog.LevelInfo}
+	Idle    = Icon{"💤", "[idle]", slog.LevelInfo}
+	Wake    = Icon{"⏰", "[idle]", slog.LevelInfo}
+	Mount   = Icon{"💽", "[disk]", slog.LevelInfo}
+	Warning = Icon{"⚠️ ", "[warn]", slog.LevelWarn}
+	Stop    = Icon{"🛑", "[stop]", slog.LevelInfo}
+)
+
+// emoji is off under --no-emoji and on consoles that cannot render it
+var emoji atomic.Bool
+
+// IconKey is the attribute a status line's icon is logged under
+const IconKey = "icon"
+
+// logger is the shared logger main installs with SetLogger; until then
+// lines are printed to stdout
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	emoji.Store(supportsEmoji())
+	logger.Store(slog.New(NewHandler(os.Stdout, nil)))
+}
+
+// Setup switches the console to UTF-8 where that is needed, and turns
+// emoji off when disabled is set or the console cannot render them
+func Setup(disabled bool) {
+	enableUTF8()
+	emoji.Store(!disabled && supportsEmoji())
+}
+
+// String returns the emoji, or the ASCII tag when emoji are off
+func (i Icon) String() string {
+	if emoji.Load() {
+		return i.emoji
+	}
+	return i.ascii
+}
+
+// LogValue logs the icon as it is printed
+func (i Icon) LogValue() slog.Value {
+	return slog.StringValue(i.String())
+}
+
+// SetLogger makes l the logger every status line goes to
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// Logger returns the shared logger
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// Printf logs a status line prefixed with icon
+func Printf(icon Icon, format string, args ...any) {
+	Println(icon, fmt.Sprintf(format, args...))
+}
+
+// Println logs a status line prefixed with icon
+func Println(icon Icon, text string) {
+	logger.Load().Log(context.Background(), icon.level, text, IconKey, icon)
+}
+
+// Infof logs a line without an icon
+func Infof(format string, args ...any) {
+	logger.Load().Info(fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a warning without an icon, such as about a misbehaving client
+func Warnf(format string, args ...any) {
+	logger.Load().Warn(fmt.Sprintf(format, args...))
+}
+
+// Errorf logs an error
+func Errorf(format string, args ...any) {
+	logger.Load().Error(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package console
+
+// supportsEmoji reports true: terminals on other platforms render UTF-8
+func supportsEmoji() bool { return true }
+
+func enableUTF8() {}
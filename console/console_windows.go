@@ -0,0 +1,29 @@
+//go:build windows
+
+package console
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// cpUTF8 is the UTF-8 code page
+const cpUTF8 = 65001
+
+// supportsEmoji reports whether the monitor runs in a terminal that draws
+// emoji. The legacy console host behind cmd.exe and PowerShell shows them
+// as boxes even in UTF-8, so only terminals known to render them qualify:
+// Windows Terminal, VS Code, ConEmu and mintty.
+func supportsEmoji() bool {
+	return os.Getenv("WT_SESSION") != "" ||
+		os.Getenv("TERM_PROGRAM") != "" ||
+		os.Getenv("ConEmuANSI") == "ON" ||
+		os.Getenv("TERM") != ""
+}
+
+// enableUTF8 switches the console's output code page to UTF-8, so the
+// log's non-ASCII text, the ASCII-tagged lines included, is not garbled
+func enableUTF8() {
+	windows.SetConsoleOutputCP(cpUTF8)
+}
@@ -0,0 +1,118 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Handler is the slog.Handler printing records as status lines: the icon,
+// if the record has one, the message, then its other attributes as
+// key=value pairs
+type Handler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewHandler returns a Handler writing lines to w. Records below level are
+// dropped; a nil level keeps info and above.
+func NewHandler(w io.Writer, level slog.Leveler) *Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &Handler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes the record as one line, in a single write
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	var icon string
+	var fields []string
+	var add func(prefix string, a slog.Attr)
+	add = func(prefix string, a slog.Attr) {
+		a.Value = a.Value.Resolve()
+		switch {
+		case a.Equal(slog.Attr{}):
+		case a.Key == IconKey && prefix == "":
+			icon = a.Value.String()
+		case a.Value.Kind() == slog.KindGroup:
+			for _, ga := range a.Value.Group() {
+				add(prefix+a.Key+".", ga)
+			}
+		default:
+			fields = append(fields, fmt.Sprintf("%s%s=%s", prefix, a.Key, quote(a.Value.String())))
+		}
+	}
+	for _, a := range h.attrs {
+		add("", a)
+	}
+	prefix := h.prefix()
+	r.Attrs(func(a slog.Attr) bool {
+		add(prefix, a)
+		return true
+	})
+
+	var line strings.Builder
+	if icon != "" {
+		line.WriteString(icon + " ")
+	}
+	line.WriteString(r.Message)
+	for _, f := range fields {
+		line.WriteString(" " + f)
+	}
+	line.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, line.String())
+	return err
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	prefix := h.prefix()
+	next := *h
+	next.attrs = append([]slog.Attr(nil), h.attrs...)
+	for _, a := range attrs {
+		a.Key = prefix + a.Key
+		next.attrs = append(next.attrs, a)
+	}
+	return &next
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	next := *h
+	next.groups = append(append([]string(nil), h.groups...), name)
+	return &next
+}
+
+// prefix qualifies attribute keys with the open groups
+func (h *Handler) prefix() string {
+	var prefix string
+	for _, g := range h.groups {
+		prefix += g + "."
+	}
+	return prefix
+}
+
+// quote quotes values that would otherwise run into the next field
+func quote(v string) string {
+	if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+		return fmt.Sprintf("%q", v)
+	}
+	return v
+}
@@ -0,0 +1,34 @@
+package console
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	emoji.Store(false)
+	t.Cleanup(func() { emoji.Store(supportsEmoji()) })
+
+	tests := []struct {
+		name string
+		log  func(*slog.Logger)
+		want string
+	}{
+		{"plain line", func(l *slog.Logger) { l.Info("Added subscriber, total: 1") }, "Added subscriber, total: 1\n"},
+		{"icon", func(l *slog.Logger) { l.Info("Mounted /data", IconKey, Mount) }, "[disk] Mounted /data\n"},
+		{"attributes", func(l *slog.Logger) { l.Info("http request", "method", "GET", "path", "/api/metrics", "status", 200) }, "http request method=GET path=/api/metrics status=200\n"},
+		{"quoted value", func(l *slog.Logger) { l.Info("job", "cmd", "redis-cli llen") }, `job cmd="redis-cli llen"` + "\n"},
+		{"With and groups", func(l *slog.Logger) { l.With("id", 7).WithGroup("req").Info("done", "ms", 3) }, "done id=7 req.ms=3\n"},
+		{"below the level", func(l *slog.Logger) { l.Debug("hidden") }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(slog.New(NewHandler(&buf, nil)))
+			if got := buf.String(); got != tt.want {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
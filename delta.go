@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/metrics"
+)
+
+// deltaHistory is how many recent snapshots /api/metrics?since= can diff
+// against; older tokens get a full snapshot
+const deltaHistory = 64
+
+// metricsTokenHeader carries the token of the snapshot in every
+// /api/metrics response
+const metricsTokenHeader = "X-Metrics-Token"
+
+// MetricsDelta is the /api/metrics?since= response. Changed holds the
+// top-level snapshot fields (system, cpu, disk, ...) whose value differs
+// from the snapshot the token named, and Removed the fields it had that are
+// now absent. Full is set when the token was unknown or expired, in which
+// case Changed holds every field.
+type MetricsDelta struct {
+	Token   string                     `json:"token"`
+	Full    bool                       `json:"full"`
+	Changed map[string]json.RawMessage `json:"changed"`
+	Removed []string                   `json:"removed,omitempty"`
+}
+
+// deltaLog keeps the encoded fields of recent snapshots by sequence number.
+// Tokens are "<epoch>-<sequence>", where the epoch is the process start
+// time, so a token from before a restart is unknown rather than wrong.
+type deltaLog struct {
+	epoch string
+
+	mu      sync.Mutex
+	entries []deltaEntry
+	next    int
+}
+
+type deltaEntry struct {
+	seq    uint64
+	fields map[string]json.RawMessage
+}
+
+func newDeltaLog() *deltaLog {
+	return &deltaLog{
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		entries: make([]deltaEntry, deltaHistory),
+	}
+}
+
+// add records a presented snapshot under seq, replacing the oldest entry
+func (l *deltaLog) add(seq uint64, snapshot *metrics.Snapshot) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		console.Errorf("Error encoding snapshot for deltas: %v", err)
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		console.Errorf("Error encoding snapshot for deltas: %v", err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = deltaEntry{seq: seq, fields: fields}
+	l.next = (l.next + 1) % len(l.entries)
+}
+
+// token returns the token naming the snapshot recorded under seq
+func (l *deltaLog) token(seq uint64) string {
+	return l.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// lookup returns the fields recorded for a token, if still retained
+func (l *deltaLog) lookup(token string) (map[string]json.RawMessage, bool) {
+	epoch, seqValue, found := strings.Cut(token, "-")
+	if !found || epoch != l.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseUint(seqValue, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range l.entries {
+		if entry.fields != nil && entry.seq == seq {
+			return entry.fields, true
+		}
+	}
+	return nil, false
+}
+
+// since diffs the snapshot recorded under seq against the one named by
+// token
+func (l *deltaLog) since(token string, seq uint64) MetricsDelta {
+	current, _ := l.lookup(l.token(seq))
+	delta := MetricsDelta{
+		Token:   l.token(seq),
+		Changed: make(map[string]json.RawMessage),
+	}
+
+	previous, ok := l.lookup(token)
+	if !ok {
+		delta.Full = true
+		delta.Changed = current
+		return delta
+	}
+
+	for name, value := range current {
+		if old, ok := previous[name]; !ok || !bytes.Equal(old, value) {
+			delta.Changed[name] = value
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			delta.Removed = append(delta.Removed, name)
+		}
+	}
+	sort.Strings(delta.Removed)
+	return delta
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"system-monitor/metrics"
+)
+
+func TestDiskReportCSVGrowth(t *testing.T) {
+	tests := []struct {
+		growth float64
+		want   string
+	}{
+		{-0.3, "0"},
+		{0.4, "0"},
+		{-1024.6, "-1025"},
+		{2048, "2048"},
+	}
+	for _, tt := range tests {
+		report := metrics.DiskReport{Mounts: []metrics.MountReport{{Mountpoint: "/", GrowthBytesPerDay: tt.growth}}}
+		body, err := diskReportCSV(report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rows[1][7]; got != tt.want {
+			t.Errorf("growth %v written as %q, want %q", tt.growth, got, tt.want)
+		}
+	}
+}
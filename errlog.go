@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"system-monitor/console"
+)
+
+// A failure that repeats is logged once, then summarized every
+// errorSummaryCount occurrences or errorSummaryPeriod, whichever comes
+// first, so a source failing every tick doesn't bury the rest of the log
+const (
+	errorSummaryCount  = 100
+	errorSummaryPeriod = time.Minute
+	// errorThrottleKeys bounds how many distinct messages are tracked; past
+	// it, new messages are logged unthrottled
+	errorThrottleKeys = 32
+)
+
+// repeatedError counts the occurrences of one message since it was last
+// logged
+type repeatedError struct {
+	suppressed int
+	total      int
+	logged     time.Time
+}
+
+// errorThrottle rate-limits the log lines for one failing source. Errors are
+// told apart by message, and the counts reset once the source succeeds.
+type errorThrottle struct {
+	// what names the failing action in log lines, as in "Error <what>"
+	what string
+
+	mu     sync.Mutex
+	errors map[string]*repeatedError
+}
+
+func newErrorThrottle(what string) *errorThrottle {
+	return &errorThrottle{what: what}
+}
+
+// failed logs err when it is new or a summary is due, and otherwise only
+// counts it
+func (t *errorThrottle) failed(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	message := err.Error()
+	now := time.Now()
+	repeated, ok := t.errors[message]
+	if !ok {
+		if t.errors == nil {
+			t.errors = make(map[string]*repeatedError)
+		}
+		if len(t.errors) < errorThrottleKeys {
+			t.errors[message] = &repeatedError{total: 1, logged: now}
+		}
+		console.Errorf("Error %s: %v", t.what, err)
+		return
+	}
+
+	repeated.total++
+	repeated.suppressed++
+	if repeated.suppressed < errorSummaryCount && now.Sub(repeated.logged) < errorSummaryPeriod {
+		return
+	}
+	console.Errorf("Error %s (%d more times in %s, %d in all): %v",
+		t.what, repeated.suppressed, now.Sub(repeated.logged).Round(time.Second), repeated.total, err)
+	repeated.suppressed = 0
+	repeated.logged = now
+}
+
+// cleared resets the counts after a success, noting the recovery when
+// failures had been logged
+func (t *errorThrottle) cleared() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.errors) == 0 {
+		return
+	}
+	total := 0
+	for _, repeated := range t.errors {
+		total += repeated.total
+	}
+	t.errors = nil
+	console.Printf(console.Ready, "Resumed %s after %d failures", t.what, total)
+}
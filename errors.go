@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"system-monitor/console"
+	"system-monitor/templates"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIError is the JSON body returned by /api endpoints on failure
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Detail == "" {
+		return e.Message
+	}
+	return e.Message + ": " + e.Detail
+}
+
+// StatusCode is the HTTP status the error is answered with, so telemetry
+// counts the request under it
+func (e *APIError) StatusCode() int {
+	return e.Code
+}
+
+// newAPIError creates an error that the error handler writes as an APIError
+func newAPIError(code int, message, detail string) *APIError {
+	return &APIError{
+		Code:    code,
+		Message: message,
+		Detail:  detail,
+	}
+}
+
+// writeAPIError writes err as a JSON APIError response
+func writeAPIError(c *fiber.Ctx, err *APIError) error {
+	return c.Status(err.Code).JSON(err)
+}
+
+// isAPIRoute reports whether the request targets the JSON API
+func isAPIRoute(c *fiber.Ctx) bool {
+	path := c.Path()
+	return path == "/api" || strings.HasPrefix(path, "/api/")
+}
+
+// errorHandler replaces Fiber's default so API routes always answer with an
+// APIError while dashboard routes keep an HTML error page
+func errorHandler(c *fiber.Ctx, err error) error {
+	apiErr := toAPIError(err)
+
+	if isAPIRoute(c) {
+		return writeAPIError(c, apiErr)
+	}
+
+	var buf bytes.Buffer
+	if renderErr := templates.ErrorPage(apiErr.Code, apiErr.Message).Render(c.Context(), &buf); renderErr != nil {
+		return c.Status(apiErr.Code).SendString(apiErr.Message)
+	}
+	c.Set("Content-Type", "text/html")
+	return c.Status(apiErr.Code).SendString(buf.String())
+}
+
+// toAPIError maps any handler error to an APIError. Unexpected errors become
+// a 500 whose detail carries the original message.
+func toAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return newAPIError(fiberErr.Code, fiberErr.Message, "")
+	}
+
+	console.Errorf("Unhandled request error: %v", err)
+	return newAPIError(fiber.StatusInternalServerError, "internal server error", err.Error())
+}
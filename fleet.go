@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"system-monitor/metrics"
+	"system-monitor/templates"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fleetRequestTimeout bounds each poll of a fleet target, so an unreachable
+// host is marked down instead of stalling its poller
+const fleetRequestTimeout = 5 * time.Second
+
+// fleetHost is the outcome of the latest poll of one --fleet-target. The
+// snapshot from the last successful poll is kept while the host is down.
+type fleetHost struct {
+	target   FleetTarget
+	snapshot *metrics.Snapshot
+	err      error
+	polled   time.Time
+}
+
+// fleet polls other monitor instances' /api/metrics for the /fleet overview
+type fleet struct {
+	client *http.Client
+	mu     sync.RWMutex
+	hosts  []fleetHost
+}
+
+func newFleet(targets []FleetTarget) *fleet {
+	f := &fleet{
+		client: &http.Client{Timeout: fleetRequestTimeout},
+		hosts:  make([]fleetHost, len(targets)),
+	}
+	for i, target := range targets {
+		f.hosts[i].target = target
+	}
+	return f
+}
+
+// start polls every target immediately and then once per interval, each on
+// its own goroutine so a slow host doesn't delay the others
+func (f *fleet) start(interval time.Duration) {
+	for i := range f.hosts {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				f.poll(i)
+				<-ticker.C
+			}
+		}()
+	}
+}
+
+// poll fetches the latest snapshot of the ith target
+func (f *fleet) poll(i int) {
+	f.mu.RLock()
+	target := f.hosts[i].target
+	f.mu.RUnlock()
+
+	snapshot, err := f.fetch(target)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	host := &f.hosts[i]
+	host.err = err
+	host.polled = time.Now()
+	if err == nil {
+		host.snapshot = snapshot
+	}
+}
+
+func (f *fleet) fetch(target FleetTarget) (*metrics.Snapshot, error) {
+	resp, err := f.client.Get(target.URL + "/api/metrics")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr APIError
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("%s: %s", resp.Status, apiErr.Message)
+		}
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+
+	var snapshot metrics.Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decoding metrics: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// list returns the hosts in --fleet-target order
+func (f *fleet) list() []fleetHost {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]fleetHost(nil), f.hosts...)
+}
+
+// host returns the target with the given name
+func (f *fleet) host(name string) (fleetHost, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, host := range f.hosts {
+		if host.target.Name == name {
+			return host, true
+		}
+	}
+	return fleetHost{}, false
+}
+
+// fleetRow summarizes a host for the overview table, rating it against the
+// local --health-thresholds
+func (s *Server) fleetRow(host fleetHost) templates.FleetRow {
+	cfg := s.getConfig()
+	row := templates.FleetRow{
+		Name: host.target.Name,
+		URL:  host.target.URL,
+	}
+	if !host.polled.IsZero() {
+		row.Polled = host.polled.In(cfg.Location).Format(timestampLayout)
+	}
+	if host.err != nil {
+		row.Error = host.err.Error()
+	}
+
+	snapshot := host.snapshot
+	if snapshot == nil || host.err != nil {
+		return row
+	}
+	row.Up = true
+	row.Health = metrics.EvaluateHealth(snapshot.System, snapshot.CPU, snapshot.Disk, cfg.Health)
+
+	sample := snapshot.Sample()
+	if snapshot.System != nil {
+		row.Hostname = snapshot.System.Hostname
+		row.Mem = &sample.MemUsedPercent
+	}
+	if snapshot.CPU != nil {
+		row.CPU = &sample.CPUPercent
+	}
+	if snapshot.Disk != nil {
+		row.Disk = &sample.DiskUsedPercent
+	}
+	return row
+}
+
+func (s *Server) fleetHandler(c *fiber.Ctx) error {
+	return renderHTML(c, templates.Fleet(len(s.fleet.list())))
+}
+
+// fleetHostsHandler renders the overview rows, polled by the fleet page
+func (s *Server) fleetHostsHandler(c *fiber.Ctx) error {
+	var rows []templates.FleetRow
+	for _, host := range s.fleet.list() {
+		rows = append(rows, s.fleetRow(host))
+	}
+	return renderHTML(c, templates.FleetRows(rows))
+}
+
+// fleetHostHandler drills down into one host, rendering its last snapshot
+// as a static dashboard
+func (s *Server) fleetHostHandler(c *fiber.Ctx) error {
+	host, ok := s.fleet.host(c.Params("name"))
+	if !ok {
+		return fiber.NewError(fiber.StatusNotFound, "no fleet target named "+c.Params("name"))
+	}
+	snapshot := host.snapshot
+	if snapshot == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, host.target.Name+" has not been reached yet")
+	}
+
+	panels := s.snapshotPanels(snapshot, nil, snapshot.Dirs != nil, snapshot.Containers != nil)
+	if snapshot.Processes != nil {
+		panels = append(panels, processPanel(snapshot.Processes))
+	}
+	opts := snapshotOptions(snapshot)
+	opts.FleetHost = host.target.Name
+	return renderHTML(c, staticIndex(c.Context(), opts, panels))
+}
+
+// snapshotOptions shows the panels a polled snapshot has data for
+func snapshotOptions(snapshot *metrics.Snapshot) templates.IndexOptions {
+	return templates.IndexOptions{
+		ShowSystem:     snapshot.System != nil,
+		ShowCPU:        snapshot.CPU != nil,
+		ShowDisk:       snapshot.Disk != nil,
+		ShowNetwork:    snapshot.Network != nil,
+		ShowProcesses:  snapshot.Processes != nil,
+		ShowLoad:       snapshot.Load != nil,
+		ShowNUMA:       snapshot.NUMA != nil,
+		ShowSwap:       snapshot.Swap != nil,
+		ShowPressure:   snapshot.Pressure != nil,
+		ShowFD:         snapshot.FD != nil,
+		ShowClock:      snapshot.Clock != nil,
+		ShowContainers: snapshot.Containers != nil,
+		ShowDirs:       snapshot.Dirs != nil,
+		ShowDiskHealth: snapshot.DiskHealth != nil,
+		ShowCustom:     snapshot.Custom != nil,
+	}
+}
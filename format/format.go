@@ -0,0 +1,166 @@
+// Package format renders byte counts, rates and counts for display in the
+// dashboard and the terminal
+package format
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Locale is the digit grouping and decimal mark used for displayed numbers
+type Locale struct {
+	Group   string
+	Decimal string
+}
+
+// locales are the grouping styles selectable with --locale
+var locales = map[string]Locale{
+	"en":   {Group: ",", Decimal: "."},
+	"de":   {Group: ".", Decimal: ","},
+	"fr":   {Group: " ", Decimal: ","},
+	"ch":   {Group: "'", Decimal: "."},
+	"none": {Group: "", Decimal: "."},
+}
+
+// current is set once at startup, before anything is rendered
+var current = locales["en"]
+
+// LocaleNames returns the names accepted by ParseLocale, sorted
+func LocaleNames() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseLocale looks up a grouping style by name
+func ParseLocale(name string) (Locale, error) {
+	locale, ok := locales[name]
+	if !ok {
+		return Locale{}, fmt.Errorf("must be one of %s", strings.Join(LocaleNames(), ", "))
+	}
+	return locale, nil
+}
+
+// SetLocale selects the grouping style for every formatted number. It is not
+// safe to call while rendering.
+func SetLocale(locale Locale) {
+	current = locale
+}
+
+// Units is the convention for byte sizes: binary multiples of 1024 with IEC
+// names, or decimal multiples of 1000 with SI names
+type Units struct {
+	Base  float64
+	Names []string
+}
+
+// unitSystems are the conventions selectable with --units
+var unitSystems = map[string]Units{
+	"binary":  {Base: 1024, Names: []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}},
+	"decimal": {Base: 1000, Names: []string{"B", "kB", "MB", "GB", "TB", "PB"}},
+}
+
+// units is set once at startup, before anything is rendered
+var units = unitSystems["binary"]
+
+// UnitNames returns the names accepted by ParseUnits, sorted
+func UnitNames() []string {
+	names := make([]string, 0, len(unitSystems))
+	for name := range unitSystems {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseUnits looks up a byte size convention by name
+func ParseUnits(name string) (Units, error) {
+	u, ok := unitSystems[name]
+	if !ok {
+		return Units{}, fmt.Errorf("must be one of %s", strings.Join(UnitNames(), ", "))
+	}
+	return u, nil
+}
+
+// SetUnits selects the convention for every formatted byte size. It is not
+// safe to call while rendering.
+func SetUnits(u Units) {
+	units = u
+}
+
+// Bytes renders a byte count with an adaptive unit
+func Bytes(b uint64) string {
+	value := float64(b)
+	unit := 0
+	for value >= units.Base && unit < len(units.Names)-1 {
+		value /= units.Base
+		unit++
+	}
+	if unit == 0 {
+		return Uint(b) + " " + units.Names[unit]
+	}
+	return Float(value, 1) + " " + units.Names[unit]
+}
+
+// Mebibytes renders a size counted in MiB, as memory is reported, as whole
+// MiB or MB in the selected convention
+func Mebibytes(mib uint64) string {
+	if units.Base == 1024 {
+		return Uint(mib) + " " + units.Names[2]
+	}
+	return Uint(mib*1024*1024/1_000_000) + " " + units.Names[2]
+}
+
+// Rate renders a bytes-per-second rate with an adaptive unit
+func Rate(bytesPerSec float64) string {
+	return Bytes(uint64(bytesPerSec)) + "/s"
+}
+
+// Count renders an integer with thousands separators
+func Count(n int) string {
+	digits := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + group(digits[1:])
+	}
+	return group(digits)
+}
+
+// Uint renders an unsigned integer with thousands separators
+func Uint(n uint64) string {
+	return group(strconv.FormatUint(n, 10))
+}
+
+// Float renders v with prec decimals, grouping the integer part
+func Float(v float64, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, ok := strings.Cut(s, ".")
+	if !ok {
+		return sign + group(whole)
+	}
+	return sign + group(whole) + current.Decimal + frac
+}
+
+// group inserts the locale's separator between thousands of a digit string
+func group(digits string) string {
+	if current.Group == "" {
+		return digits
+	}
+
+	var out strings.Builder
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteString(current.Group)
+		}
+		out.WriteByte(digits[i])
+	}
+	return out.String()
+}
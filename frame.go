@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"slices"
+	"sort"
+
+	"system-monitor/console"
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+	"system-monitor/templates"
+
+	"github.com/a-h/templ"
+)
+
+// timestampLayout includes the zone abbreviation so viewers in other zones
+// can tell when the data was taken
+const timestampLayout = "2006-01-02 15:04:05 MST"
+
+// panel pairs a dashboard container with the component that fills it
+type panel struct {
+	id        string
+	name      string
+	component templ.Component
+}
+
+// framePanels builds every enabled panel for a snapshot except the process
+// table, which renderFrame may swap out to respect the frame size cap
+func (s *Server) framePanels(snapshot *metrics.Snapshot) []panel {
+	panels := s.snapshotPanels(snapshot, s.diskTrends.Trends(), s.collectors.dirs != nil, s.collectors.docker != nil)
+	return append(panels, s.maintenancePanel())
+}
+
+// maintenancePanel shows the banner while a maintenance window is active,
+// and clears it once the window ends
+func (s *Server) maintenancePanel() panel {
+	var until string
+	status := s.quietStatus()
+	if status.Until != nil {
+		until = status.Until.Format(timestampLayout)
+	}
+	return panel{"maintenance-banner", "maintenance", templates.MaintenanceBanner(status.Active, until, status.Reason)}
+}
+
+// snapshotPanels builds the panels for any snapshot, local or polled from a
+// fleet target. The directory and container panels are included when
+// showDirs and showContainers are set, even while they have no rows.
+func (s *Server) snapshotPanels(snapshot *metrics.Snapshot, trends map[string]metrics.Trend, showDirs, showContainers bool) []panel {
+	cfg := s.getConfig()
+	system, disk, cpu, network := snapshot.System, snapshot.Disk, snapshot.CPU, snapshot.Network
+
+	panels := []panel{
+		{"health-summary", "health", templates.HealthSummary(metrics.EvaluateHealth(system, cpu, disk, cfg.Health))},
+		{"update-timestamp", "status", templates.StatusUpdate(snapshot.Time.In(cfg.Location).Format(timestampLayout))},
+	}
+	if system != nil {
+		// Zombies are counted by the process collector
+		var zombies *int
+		if snapshot.Processes != nil {
+			zombies = snapshot.Processes.Zombies
+		}
+		panels = append(panels, panel{"system-data", "system", templates.SystemData(
+			system.OS,
+			system.Platform,
+			system.Hostname,
+			system.Procs,
+			zombies,
+			cfg.ZombieWarning,
+			system.TotalMem,
+			system.FreeMem,
+			system.UsedPercent,
+			system.Unavailable,
+			cfg.Health.Memory,
+		)})
+	}
+	if cpu != nil {
+		panels = append(panels, panel{"cpu-data", "CPU", templates.CPUData(
+			cpu,
+			snapshot.CPUAverage,
+			snapshot.Rolling,
+			system != nil,
+			cfg.CPULayout,
+			cfg.Health.CPU,
+		)})
+	}
+	if disk != nil {
+		panels = append(panels, panel{"disk-data", "disk", templates.DiskData(
+			disk.Path,
+			disk.Total,
+			disk.Used,
+			disk.Free,
+			disk.UsedPercent,
+			disk.Mounts,
+			disk.IO,
+			trends,
+			cfg.Health.Disk,
+		)})
+	}
+	if network != nil {
+		panels = append(panels, panel{"network-data", "network", templates.NetworkData(
+			network.Interfaces,
+			network.Total,
+			cfg.NetView != netViewTotal,
+			cfg.NetView != netViewInterfaces,
+		)})
+	}
+	if load := snapshot.Load; load != nil {
+		panels = append(panels, panel{"load-data", "load", templates.LoadData(load, cfg.LoadPrecision)})
+	}
+	if snapshot.NUMA != nil {
+		panels = append(panels, panel{"numa-data", "NUMA", templates.NUMAData(snapshot.NUMA)})
+	}
+	if snapshot.Swap != nil {
+		panels = append(panels, panel{"swap-data", "swap", templates.SwapData(snapshot.Swap)})
+	}
+	if snapshot.Pressure != nil {
+		panels = append(panels, panel{"pressure-data", "pressure", templates.PressureData(snapshot.Pressure)})
+	}
+	if snapshot.FD != nil {
+		panels = append(panels, panel{"fd-data", "file descriptor", templates.FDData(snapshot.FD)})
+	}
+	if snapshot.Clock != nil {
+		panels = append(panels, panel{"clock-data", "clock", templates.ClockData(snapshot.Clock, cfg.ClockSkewWarn)})
+	}
+	if showDirs && !slices.Contains(snapshot.Denied, collectorDirs) {
+		panels = append(panels, panel{"dir-data", "directory", templates.DirData(snapshot.Dirs)})
+	}
+	if showContainers && !slices.Contains(snapshot.Denied, collectorDocker) {
+		panels = append(panels, panel{"container-data", "container", templates.ContainerData(snapshot.Containers)})
+	}
+	if snapshot.DiskHealth != nil {
+		panels = append(panels, panel{"disk-health-data", "disk health", templates.DiskHealthData(snapshot.DiskHealth)})
+	}
+	if snapshot.Custom != nil {
+		panels = append(panels, panel{"custom-data", "custom metric", templates.CustomMetricData(snapshot.Custom)})
+	}
+	for _, name := range snapshot.Denied {
+		if id, ok := collectorPanelIDs[name]; ok {
+			panels = append(panels, panel{id, name, templates.PanelDenied(name)})
+		}
+	}
+
+	return panels
+}
+
+// collectorPanelIDs maps collector names to the dashboard container each
+// one fills
+var collectorPanelIDs = map[string]string{
+	collectorSystem:    "system-data",
+	collectorCPU:       "cpu-data",
+	collectorDisk:      "disk-data",
+	collectorNetwork:   "network-data",
+	collectorProcesses: "process-data",
+	collectorLoad:      "load-data",
+	collectorNUMA:      "numa-data",
+	collectorSwap:      "swap-data",
+	collectorPressure:  "pressure-data",
+	collectorFD:        "fd-data",
+	collectorClock:     "clock-data",
+	collectorDocker:    "container-data",
+	collectorDirs:      "dir-data",
+	collectorSmart:     "disk-health-data",
+	collectorCustom:    "custom-data",
+}
+
+// processPanel renders the process table panel
+func processPanel(processes *handlers.ProcessInfo) panel {
+	if processes.Groups != nil {
+		return panel{"process-data", "process", templates.ProcessGroupData(processes.Groups, processes.Total, processes.IO)}
+	}
+	return panel{"process-data", "process", templates.ProcessData(processes.Processes, processes.Total, processes.IO)}
+}
+
+// renderFrame renders a snapshot into an HTMX frame of hx-swap-oob fragments
+func (s *Server) renderFrame(ctx context.Context, snapshot *metrics.Snapshot) []byte {
+	var panels []templates.FramePanel
+	for _, p := range s.framePanels(snapshot) {
+		panels = append(panels, framePanel(ctx, p))
+	}
+
+	processes := snapshot.Processes
+	if processes == nil {
+		return s.assembleFrame(ctx, snapshot, panels)
+	}
+
+	// The process table is the only panel that grows with the host, so if it
+	// would push the frame over the cap it is cut to the most rows that fit,
+	// searching on the row count as the frame grows with it
+	frame := s.assembleFrame(ctx, snapshot, append(panels, framePanel(ctx, processPanel(processes))))
+	maxBytes := s.getConfig().MaxFrameBytes
+	if maxBytes <= 0 || len(frame) <= maxBytes {
+		return frame
+	}
+	render := func(rows int) []byte {
+		return s.assembleFrame(ctx, snapshot, append(panels, framePanel(ctx, processPanel(trimProcesses(processes, rows)))))
+	}
+	rows := sort.Search(processRows(processes), func(rows int) bool {
+		return len(render(rows+1)) > maxBytes
+	})
+	if fits := render(rows); len(fits) <= maxBytes {
+		return fits
+	}
+	// Not even an empty table fits
+	omitted := panel{"process-data", "process", templates.ProcessOmitted(processes.Total, maxBytes)}
+	return s.assembleFrame(ctx, snapshot, append(panels, framePanel(ctx, omitted)))
+}
+
+// processRows counts the process table's rows: its groups when grouped
+func processRows(processes *handlers.ProcessInfo) int {
+	if processes.Groups != nil {
+		return len(processes.Groups)
+	}
+	return len(processes.Processes)
+}
+
+// trimProcesses returns processes cut to their first rows, leaving the
+// sorted slices shared with the snapshot untouched. The uncut total is
+// kept, so the table counts the rows left out.
+func trimProcesses(processes *handlers.ProcessInfo, rows int) *handlers.ProcessInfo {
+	trimmed := *processes
+	if trimmed.Groups != nil {
+		trimmed.Groups = trimmed.Groups[:rows]
+	} else {
+		trimmed.Processes = trimmed.Processes[:rows]
+	}
+	return &trimmed
+}
+
+// framePanel renders a panel's content for assembly into a frame
+func framePanel(ctx context.Context, p panel) templates.FramePanel {
+	return templates.FramePanel{ID: p.id, Name: p.name, HTML: template.HTML(renderPanelContent(ctx, p))}
+}
+
+// assembleFrame lays rendered panels out with the --frame-template, else as
+// the built-in frame. A failing template falls back to the built-in frame so
+// the dashboard keeps updating.
+func (s *Server) assembleFrame(ctx context.Context, snapshot *metrics.Snapshot, panels []templates.FramePanel) []byte {
+	var frame bytes.Buffer
+	if layout := s.getConfig().FrameTemplate; layout != nil {
+		err := layout.Execute(&frame, templates.FrameData{Panels: panels, Snapshot: snapshot})
+		if err == nil {
+			return frame.Bytes()
+		}
+		console.Errorf("Error executing --frame-template: %v", err)
+		frame.Reset()
+	}
+	if err := templates.Frame(panels).Render(ctx, &frame); err != nil {
+		// The panels are already rendered, so this only fails when cancelled
+		return nil
+	}
+	return frame.Bytes()
+}
+
+// renderPanelContent renders a panel's component. A failing component is
+// replaced by an error placeholder so the rest of the page still updates.
+// A cancelled render yields nothing, as no one is waiting for it.
+func renderPanelContent(ctx context.Context, p panel) []byte {
+	var content bytes.Buffer
+	if err := p.component.Render(ctx, &content); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		console.Errorf("Error rendering %s component: %v", p.name, err)
+		content.Reset()
+		if err := templates.PanelError(p.name).Render(ctx, &content); err != nil {
+			// The placeholder is static, so this only fails on a broken writer
+			content.Reset()
+		}
+	}
+	return content.Bytes()
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+	"system-monitor/templates"
+
+	"github.com/a-h/templ"
+)
+
+// TestRenderPanelFailureKeepsOtherPanels publishes a frame with a failing
+// panel and checks what a subscriber is sent still updates the others
+func TestRenderPanelFailureKeepsOtherPanels(t *testing.T) {
+	s := newPublishServer()
+	subscriber := newTestSubscriber(1)
+	s.subscribers[subscriber] = struct{}{}
+
+	failing := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		io.WriteString(w, "partial output")
+		return errors.New("broken template")
+	})
+	working := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, "<span>cpu ok</span>")
+		return err
+	})
+
+	ctx := context.Background()
+	panels := []templates.FramePanel{
+		framePanel(ctx, panel{"system-data", "system", failing}),
+		framePanel(ctx, panel{"cpu-data", "CPU", working}),
+	}
+	s.publishMsg(map[string][]byte{formatHTML: s.assembleFrame(ctx, nil, panels)}, metrics.HealthLevels{})
+
+	var frame string
+	select {
+	case msg := <-subscriber.msgs:
+		frame = string(msg.data)
+	default:
+		t.Fatal("subscriber was sent no frame")
+	}
+	if !strings.Contains(frame, "Unable to render system data") {
+		t.Errorf("frame lacks the placeholder for the failing panel:\n%s", frame)
+	}
+	if strings.Contains(frame, "partial output") {
+		t.Errorf("frame kept the failing panel's partial output:\n%s", frame)
+	}
+	if !strings.Contains(frame, "<span>cpu ok</span>") {
+		t.Errorf("frame lacks the working panel:\n%s", frame)
+	}
+	for _, id := range []string{"#system-data", "#cpu-data"} {
+		if !strings.Contains(frame, id) {
+			t.Errorf("frame lacks %s:\n%s", id, frame)
+		}
+	}
+}
+
+func TestRenderPanelContentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	failing := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		return ctx.Err()
+	})
+	if content := renderPanelContent(ctx, panel{"cpu-data", "CPU", failing}); content != nil {
+		t.Errorf("cancelled render = %q, want nothing", content)
+	}
+}
+
+// TestRenderFrameTrimsProcesses checks a process table over --max-frame-bytes
+// is cut to the rows that fit, counting the rest, and is only replaced by
+// the placeholder when not even an empty table would fit
+func TestRenderFrameTrimsProcesses(t *testing.T) {
+	cfg, err := loadTestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newServer(cfg, nil, slog.New(slog.DiscardHandler), fakeSource{})
+	t.Cleanup(s.cancel)
+
+	processes := &handlers.ProcessInfo{Total: 100}
+	for pid := range int32(100) {
+		processes.Processes = append(processes.Processes, handlers.ProcessStat{PID: pid + 1, Name: fmt.Sprintf("worker-%d", pid+1)})
+	}
+	snapshot := &metrics.Snapshot{Time: time.Now(), Processes: processes}
+	ctx := context.Background()
+	render := func(maxBytes int) string {
+		next := *cfg
+		next.MaxFrameBytes = maxBytes
+		s.config.Store(&next)
+		return string(s.renderFrame(ctx, snapshot))
+	}
+	// frameWith is the size of the frame with the first rows of the table
+	frameWith := func(rows int) int {
+		var panels []templates.FramePanel
+		for _, p := range append(s.framePanels(snapshot), processPanel(trimProcesses(processes, rows))) {
+			panels = append(panels, framePanel(ctx, p))
+		}
+		return len(s.assembleFrame(ctx, snapshot, panels))
+	}
+	full, empty := len(render(0)), frameWith(0)
+
+	more := regexp.MustCompile(`…and (\d+) more`)
+	for _, maxBytes := range []int{empty, (empty + full) / 2, full - 1} {
+		frame := render(maxBytes)
+		if len(frame) > maxBytes {
+			t.Errorf("cap %d: frame is %d bytes", maxBytes, len(frame))
+		}
+		match := more.FindStringSubmatch(frame)
+		if match == nil {
+			t.Errorf("cap %d: frame lacks the count of rows left out", maxBytes)
+			continue
+		}
+		rows := strings.Count(frame, "worker-")
+		if left, _ := strconv.Atoi(match[1]); rows+left != processes.Total {
+			t.Errorf("cap %d: %d rows and %d left out, want %d in all", maxBytes, rows, left, processes.Total)
+		}
+		// One more row would not have fit
+		if frameWith(rows+1) <= maxBytes {
+			t.Errorf("cap %d: %d rows shown, but %d fit", maxBytes, rows, rows+1)
+		}
+	}
+	if len(processes.Processes) != 100 {
+		t.Errorf("snapshot cut to %d processes", len(processes.Processes))
+	}
+
+	if frame := render(empty - 1); !strings.Contains(frame, "processes not shown") || strings.Contains(frame, "worker-") {
+		t.Errorf("cap below an empty table: frame lacks the placeholder:\n%s", frame)
+	}
+}
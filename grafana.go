@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+
+	"system-monitor/metrics"
+	"system-monitor/telemetry"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// grafanaSchemaVersion is the dashboard JSON schema the definition is
+	// written against; Grafana migrates older versions on import
+	grafanaSchemaVersion = 39
+	// grafanaDatasource is the datasource variable every query runs against,
+	// chosen when the dashboard is imported
+	grafanaDatasource = "${datasource}"
+)
+
+// grafanaDashboard is the subset of Grafana's dashboard model the
+// definition uses
+type grafanaDashboard struct {
+	Title         string           `json:"title"`
+	UID           string           `json:"uid"`
+	Tags          []string         `json:"tags"`
+	Timezone      string           `json:"timezone"`
+	Refresh       string           `json:"refresh"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Time          grafanaRange     `json:"time"`
+	Templating    grafanaVariables `json:"templating"`
+	Panels        []grafanaPanel   `json:"panels"`
+}
+
+type grafanaRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaVariables struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name       string            `json:"name"`
+	Label      string            `json:"label"`
+	Type       string            `json:"type"`
+	Query      string            `json:"query"`
+	Datasource *grafanaRef       `json:"datasource,omitempty"`
+	Refresh    int               `json:"refresh,omitempty"`
+	IncludeAll bool              `json:"includeAll,omitempty"`
+	Multi      bool              `json:"multi,omitempty"`
+	Current    map[string]string `json:"current"`
+}
+
+type grafanaRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaPanel struct {
+	ID          int             `json:"id"`
+	Title       string          `json:"title"`
+	Type        string          `json:"type"`
+	Datasource  grafanaRef      `json:"datasource"`
+	GridPos     grafanaGridPos  `json:"gridPos"`
+	Targets     []grafanaTarget `json:"targets"`
+	FieldConfig grafanaFields   `json:"fieldConfig"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaTarget struct {
+	RefID        string     `json:"refId"`
+	Expr         string     `json:"expr"`
+	LegendFormat string     `json:"legendFormat"`
+	Datasource   grafanaRef `json:"datasource"`
+}
+
+type grafanaFields struct {
+	Defaults grafanaFieldDefaults `json:"defaults"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit       string             `json:"unit,omitempty"`
+	Min        *float64           `json:"min,omitempty"`
+	Max        *float64           `json:"max,omitempty"`
+	Thresholds *grafanaThresholds `json:"thresholds,omitempty"`
+}
+
+type grafanaThresholds struct {
+	Mode  string        `json:"mode"`
+	Steps []grafanaStep `json:"steps"`
+}
+
+type grafanaStep struct {
+	Color string   `json:"color"`
+	Value *float64 `json:"value"`
+}
+
+// grafanaPanelSpec describes one panel before layout
+type grafanaPanelSpec struct {
+	title  string
+	kind   string
+	expr   string
+	legend string
+	fields grafanaFieldDefaults
+}
+
+// newGrafanaDashboard builds a dashboard over the Pushgateway gauges and the
+// /metrics telemetry. Queries are built from the exporters' own metric name
+// constants, so a renamed series can't leave a panel behind, and usage
+// gauges are colored by the --health-thresholds in effect.
+func newGrafanaDashboard(health metrics.HealthThresholds) grafanaDashboard {
+	datasource := grafanaRef{Type: "prometheus", UID: grafanaDatasource}
+	instance := func(name string) string {
+		return fmt.Sprintf(`%s{instance=~"$instance"}`, name)
+	}
+	percent := func(threshold metrics.Threshold) grafanaFieldDefaults {
+		low, high := 0.0, 100.0
+		return grafanaFieldDefaults{Unit: "percent", Min: &low, Max: &high, Thresholds: grafanaSteps(threshold)}
+	}
+
+	specs := []grafanaPanelSpec{
+		{"CPU", "timeseries", instance(metrics.GaugeCPUPercent), "{{instance}}", percent(health.CPU)},
+		{"Memory", "timeseries", instance(metrics.GaugeMemoryPercent), "{{instance}}", percent(health.Memory)},
+		{"Disk", "timeseries", instance(metrics.GaugeDiskPercent), "{{instance}}", percent(health.Disk)},
+		{"Swap", "timeseries", instance(metrics.GaugeSwapPercent), "{{instance}}", percent(health.Memory)},
+		{"Load (1 min)", "timeseries", instance(metrics.GaugeLoad1), "{{instance}}", grafanaFieldDefaults{Unit: "short"}},
+		{"Dashboard subscribers", "stat", "sum(" + telemetry.MetricSubscribers + ")", "subscribers", grafanaFieldDefaults{Unit: "short"}},
+		{"HTTP requests", "timeseries", "sum by (code) (rate(" + telemetry.MetricRequests + "[5m]))", "{{code}}", grafanaFieldDefaults{Unit: "reqps"}},
+		{"Stream connects", "timeseries", "sum by (transport) (rate(" + telemetry.MetricConnects + "[5m]))", "{{transport}}", grafanaFieldDefaults{Unit: "ops"}},
+	}
+
+	// Two panels per row, each half the 24-column grid
+	panels := make([]grafanaPanel, len(specs))
+	for i, spec := range specs {
+		panels[i] = grafanaPanel{
+			ID:         i + 1,
+			Title:      spec.title,
+			Type:       spec.kind,
+			Datasource: datasource,
+			GridPos:    grafanaGridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8},
+			Targets: []grafanaTarget{{
+				RefID:        "A",
+				Expr:         spec.expr,
+				LegendFormat: spec.legend,
+				Datasource:   datasource,
+			}},
+			FieldConfig: grafanaFields{Defaults: spec.fields},
+		}
+	}
+
+	return grafanaDashboard{
+		Title:         "GOTTH System Monitor",
+		UID:           "gotth-system-monitor",
+		Tags:          []string{"system-monitor"},
+		Timezone:      "browser",
+		Refresh:       "30s",
+		SchemaVersion: grafanaSchemaVersion,
+		Time:          grafanaRange{From: "now-6h", To: "now"},
+		Templating: grafanaVariables{List: []grafanaVariable{
+			{
+				Name:    "datasource",
+				Label:   "Data source",
+				Type:    "datasource",
+				Query:   "prometheus",
+				Current: map[string]string{},
+			},
+			{
+				Name:       "instance",
+				Label:      "Instance",
+				Type:       "query",
+				Query:      fmt.Sprintf("label_values(%s, instance)", metrics.GaugeCPUPercent),
+				Datasource: &datasource,
+				Refresh:    2,
+				IncludeAll: true,
+				Multi:      true,
+				Current:    map[string]string{"text": "All", "value": "$__all"},
+			},
+		}},
+		Panels: panels,
+	}
+}
+
+// grafanaSteps colors a gauge green, yellow from the warning level and red
+// from critical, like the dashboard's own bars
+func grafanaSteps(threshold metrics.Threshold) *grafanaThresholds {
+	return &grafanaThresholds{
+		Mode: "absolute",
+		Steps: []grafanaStep{
+			{Color: "green"},
+			{Color: "yellow", Value: &threshold.Warning},
+			{Color: "red", Value: &threshold.Critical},
+		},
+	}
+}
+
+// grafanaDashboardHandler serves a dashboard definition to import into
+// Grafana, charting the --push-gateway gauges and the /metrics telemetry
+func (s *Server) grafanaDashboardHandler(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="grafana-dashboard.json"`)
+	return c.JSON(newGrafanaDashboard(s.getConfig().Health))
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+	"system-monitor/monitorpb"
+	"system-monitor/telemetry"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	protoenc "google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcService implements the monitorpb.Monitor service over the server's
+// subscribers, so gRPC streams are counted, paused for and pruned like the
+// websocket and SSE ones
+type grpcService struct {
+	monitorpb.UnimplementedMonitorServer
+	s *Server
+}
+
+// startGRPC serves the Monitor service on --grpc-port alongside the HTTP
+// server. It must run before the publisher, which only renders protobuf
+// frames while the gRPC server is set.
+func (s *Server) startGRPC(port int) error {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	s.grpcServer = grpc.NewServer(
+		grpc.StreamInterceptor(s.grpcAuth),
+		grpc.ForceServerCodecV2(frameCodec{encoding.GetCodecV2(protoenc.Name)}),
+	)
+	monitorpb.RegisterMonitorServer(s.grpcServer, &grpcService{s: s})
+	go func() {
+		if err := s.grpcServer.Serve(ln); err != nil {
+			console.Errorf("Error serving gRPC: %v", err)
+		}
+	}()
+	console.Printf(console.Start, "Streaming metrics over gRPC on port %d", port)
+	return nil
+}
+
+// stopGRPC ends the gRPC streams on shutdown. They return once the
+// server's context is cancelled, so a graceful stop is quick; one that
+// doesn't finish within shutdownTimeout is forced.
+func (s *Server) stopGRPC() {
+	if s.grpcServer == nil {
+		return
+	}
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.grpcServer.Stop()
+	}
+}
+
+// grpcAuth requires the admin credentials under --protect-dashboard, sent
+// as HTTP basic auth in the "authorization" metadata
+func (s *Server) grpcAuth(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	cfg := s.getConfig()
+	if !cfg.Protect {
+		return handler(srv, stream)
+	}
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	for _, value := range md.Get("authorization") {
+		user, password, ok := parseBasicAuth(value)
+		if ok &&
+			subtle.ConstantTimeCompare([]byte(user), []byte(cfg.AdminUser)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(password), []byte(cfg.AdminPassword)) == 1 {
+			return handler(srv, stream)
+		}
+	}
+	return status.Error(codes.Unauthenticated, "admin credentials required")
+}
+
+// parseBasicAuth decodes a "Basic <base64 user:password>" header value
+func parseBasicAuth(value string) (user, password string, ok bool) {
+	encoded, ok := strings.CutPrefix(value, "Basic ")
+	if !ok {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// protoFrame is a monitorpb.Snapshot the publisher has already encoded
+type protoFrame []byte
+
+// frameCodec is the proto codec, except that a protoFrame is written out as
+// it is, so the frame encoded once per tick is sent to every stream without
+// being decoded and encoded again
+type frameCodec struct {
+	encoding.CodecV2
+}
+
+func (c frameCodec) Marshal(v any) (mem.BufferSlice, error) {
+	if frame, ok := v.(protoFrame); ok {
+		return mem.BufferSlice{mem.SliceBuffer(frame)}, nil
+	}
+	return c.CodecV2.Marshal(v)
+}
+
+// StreamMetrics sends the subscriber's frames until the client goes away,
+// the server shuts down or the subscriber is dropped for falling behind.
+// Frames are shared as encoded bytes like the other formats.
+func (g *grpcService) StreamMetrics(req *monitorpb.StreamMetricsRequest, stream monitorpb.Monitor_StreamMetricsServer) error {
+	s := g.s
+	streamCtx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	var remoteAddr string
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		remoteAddr = p.Addr.String()
+	}
+	subscriber := &Subscriber{
+		msgs:        make(chan streamMessage, s.subscriberMessageBuffer),
+		done:        make(chan struct{}),
+		ctx:         streamCtx,
+		format:      formatProto,
+		alertOnly:   req.GetAlertOnly(),
+		transport:   telemetry.TransportGRPC,
+		remoteAddr:  remoteAddr,
+		connectedAt: time.Now(),
+	}
+	s.addSubscriber(subscriber)
+	defer s.removeSubscriber(subscriber)
+
+	s.telemetry.StreamConnected(telemetry.TransportGRPC)
+	defer s.telemetry.StreamDisconnected(telemetry.TransportGRPC)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-streamCtx.Done():
+			return status.Error(codes.Unavailable, "server shutting down")
+		case <-subscriber.done:
+			return status.Error(codes.ResourceExhausted, "stream fell behind and was closed; reconnect to resume")
+		case msg := <-subscriber.msgs:
+			if err := stream.SendMsg(protoFrame(msg.data)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// protoSnapshot converts a presented snapshot to its protobuf message,
+// field for field
+func protoSnapshot(snapshot *metrics.Snapshot) *monitorpb.Snapshot {
+	pb := &monitorpb.Snapshot{
+		Time:       timestamppb.New(snapshot.Time),
+		CpuAverage: snapshot.CPUAverage,
+		Denied:     snapshot.Denied,
+	}
+	if rolling := snapshot.Rolling; rolling != nil {
+		pb.Rolling = &monitorpb.RollingStats{
+			Seconds: rolling.Seconds,
+			Partial: rolling.Partial,
+			Samples: int64(rolling.Samples),
+			CpuPeak: rolling.CPUPeak,
+			CpuAvg:  rolling.CPUAvg,
+			MemPeak: rolling.MemPeak,
+			MemAvg:  rolling.MemAvg,
+		}
+	}
+	if system := snapshot.System; system != nil {
+		pb.System = &monitorpb.SystemInfo{
+			Os:          system.OS,
+			Platform:    system.Platform,
+			Hostname:    system.Hostname,
+			Procs:       system.Procs,
+			TotalMem:    system.TotalMem,
+			FreeMem:     system.FreeMem,
+			UsedPercent: system.UsedPercent,
+			Unavailable: system.Unavailable,
+		}
+	}
+	if disk := snapshot.Disk; disk != nil {
+		pb.Disk = &monitorpb.DiskInfo{
+			Path:        disk.Path,
+			Total:       disk.Total,
+			Used:        disk.Used,
+			Free:        disk.Free,
+			UsedPercent: disk.UsedPercent,
+		}
+		for _, m := range disk.Mounts {
+			pb.Disk.Mounts = append(pb.Disk.Mounts, &monitorpb.MountInfo{
+				Mountpoint:  m.Mountpoint,
+				Device:      m.Device,
+				Fstype:      m.Fstype,
+				ReadOnly:    m.ReadOnly,
+				Total:       m.Total,
+				Used:        m.Used,
+				Free:        m.Free,
+				UsedPercent: m.UsedPercent,
+			})
+		}
+		if io := disk.IO; io != nil {
+			pb.Disk.Io = &monitorpb.DiskIOInfo{
+				ReadBytes:   io.ReadBytes,
+				WriteBytes:  io.WriteBytes,
+				ReadPerSec:  io.ReadPerSec,
+				WritePerSec: io.WritePerSec,
+			}
+		}
+	}
+	if cpu := snapshot.CPU; cpu != nil {
+		pb.Cpu = &monitorpb.CPUInfo{
+			ModelName:   cpu.ModelName,
+			Family:      cpu.Family,
+			Mhz:         cpu.Mhz,
+			Percentages: cpu.Percentages,
+			Average:     cpu.Average,
+			Steal:       cpu.Steal,
+		}
+	}
+	if network := snapshot.Network; network != nil {
+		pb.Network = &monitorpb.NetworkInfo{Total: protoInterface(network.Total)}
+		for _, iface := range network.Interfaces {
+			pb.Network.Interfaces = append(pb.Network.Interfaces, protoInterface(iface))
+		}
+	}
+	if load := snapshot.Load; load != nil {
+		pb.Load = &monitorpb.LoadInfo{
+			Load1:  load.Load1,
+			Load5:  load.Load5,
+			Load15: load.Load15,
+			Cpus:   int32(load.CPUs),
+		}
+	}
+	for _, node := range snapshot.NUMA {
+		pb.Numa = append(pb.Numa, &monitorpb.NUMANodeInfo{
+			Node:        int32(node.Node),
+			TotalMem:    node.TotalMem,
+			FreeMem:     node.FreeMem,
+			UsedMem:     node.UsedMem,
+			UsedPercent: node.UsedPercent,
+		})
+	}
+	if swap := snapshot.Swap; swap != nil {
+		pb.Swap = &monitorpb.SwapInfo{
+			Total:          swap.Total,
+			Used:           swap.Used,
+			Free:           swap.Free,
+			UsedPercent:    swap.UsedPercent,
+			PagesInPerSec:  swap.PagesInPerSec,
+			PagesOutPerSec: swap.PagesOutPerSec,
+		}
+	}
+	if pressure := snapshot.Pressure; pressure != nil {
+		pb.Pressure = &monitorpb.PressureInfo{
+			Cpu:    protoPressure(pressure.CPU),
+			Memory: protoPressure(pressure.Memory),
+			Io:     protoPressure(pressure.IO),
+		}
+	}
+	if fd := snapshot.FD; fd != nil {
+		pb.Fd = &monitorpb.FDInfo{
+			Open:       fd.Open,
+			SoftLimit:  fd.SoftLimit,
+			HardLimit:  fd.HardLimit,
+			SystemOpen: fd.SystemOpen,
+			SystemMax:  fd.SystemMax,
+		}
+	}
+	if clock := snapshot.Clock; clock != nil {
+		pb.Clock = &monitorpb.ClockInfo{
+			Synced:     clock.Synced,
+			OffsetMs:   clock.OffsetMs,
+			MaxErrorMs: clock.MaxErrorMs,
+			Server:     clock.Server,
+			Stratum:    int32(clock.Stratum),
+			Error:      clock.Error,
+		}
+	}
+	for _, c := range snapshot.Containers {
+		pb.Containers = append(pb.Containers, &monitorpb.ContainerStat{
+			Id:         c.ID,
+			Name:       c.Name,
+			CpuPercent: c.CPUPercent,
+			MemUsage:   c.MemUsage,
+			MemLimit:   c.MemLimit,
+			MemPercent: c.MemPercent,
+		})
+	}
+	for _, dir := range snapshot.Dirs {
+		pb.Dirs = append(pb.Dirs, &monitorpb.DirInfo{
+			Path:    dir.Path,
+			Bytes:   dir.Bytes,
+			Files:   int64(dir.Files),
+			Skipped: int64(dir.Skipped),
+			Error:   dir.Error,
+		})
+	}
+	for _, d := range snapshot.DiskHealth {
+		pb.DiskHealth = append(pb.DiskHealth, &monitorpb.DiskHealth{
+			Device:             d.Device,
+			Model:              d.Model,
+			Serial:             d.Serial,
+			Passed:             d.Passed,
+			Temperature:        d.Temperature,
+			PowerOnHours:       d.PowerOnHours,
+			ReallocatedSectors: d.ReallocatedSectors,
+			PendingSectors:     d.PendingSectors,
+			MediaErrors:        d.MediaErrors,
+			Error:              d.Error,
+		})
+	}
+	for _, m := range snapshot.Custom {
+		pb.Custom = append(pb.Custom, &monitorpb.CustomMetric{
+			Name:   m.Name,
+			Unit:   m.Unit,
+			Value:  m.Value,
+			Error:  m.Error,
+			Stderr: m.Stderr,
+		})
+	}
+	if processes := snapshot.Processes; processes != nil {
+		pb.Processes = &monitorpb.ProcessInfo{
+			Processes: protoProcesses(processes.Processes),
+			Total:     int64(processes.Total),
+			Io:        processes.IO,
+		}
+		if processes.Zombies != nil {
+			pb.Processes.Zombies = proto.Int64(int64(*processes.Zombies))
+		}
+		for _, g := range processes.Groups {
+			pb.Processes.Groups = append(pb.Processes.Groups, &monitorpb.ProcessGroup{
+				Key:        g.Key,
+				Name:       g.Name,
+				ParentPid:  g.ParentPID,
+				Count:      int64(g.Count),
+				CpuPercent: g.CPUPercent,
+				MemPercent: g.MemPercent,
+				Rss:        g.RSS,
+				Io:         protoProcessIO(g.IO),
+				Processes:  protoProcesses(g.Processes),
+			})
+		}
+	}
+	return pb
+}
+
+func protoInterface(iface handlers.NetInterfaceInfo) *monitorpb.NetInterfaceInfo {
+	return &monitorpb.NetInterfaceInfo{
+		Name:       iface.Name,
+		BytesSent:  iface.BytesSent,
+		BytesRecv:  iface.BytesRecv,
+		SentPerSec: iface.SentPerSec,
+		RecvPerSec: iface.RecvPerSec,
+	}
+}
+
+func protoPressure(pressure handlers.ResourcePressure) *monitorpb.ResourcePressure {
+	pb := &monitorpb.ResourcePressure{Some: protoStall(pressure.Some)}
+	if pressure.Full != nil {
+		pb.Full = protoStall(*pressure.Full)
+	}
+	return pb
+}
+
+func protoStall(stall handlers.PressureStall) *monitorpb.PressureStall {
+	return &monitorpb.PressureStall{Avg10: stall.Avg10, Avg60: stall.Avg60, Avg300: stall.Avg300}
+}
+
+func protoProcesses(stats []handlers.ProcessStat) []*monitorpb.ProcessStat {
+	pb := make([]*monitorpb.ProcessStat, len(stats))
+	for i, p := range stats {
+		pb[i] = &monitorpb.ProcessStat{
+			Pid:        p.PID,
+			Ppid:       p.PPID,
+			Name:       p.Name,
+			CpuPercent: p.CPUPercent,
+			MemPercent: p.MemPercent,
+			Rss:        p.RSS,
+			Io:         protoProcessIO(p.IO),
+		}
+	}
+	return pb
+}
+
+func protoProcessIO(io *handlers.ProcessIO) *monitorpb.ProcessIO {
+	if io == nil {
+		return nil
+	}
+	return &monitorpb.ProcessIO{
+		ReadBytes:   io.ReadBytes,
+		WriteBytes:  io.WriteBytes,
+		ReadPerSec:  io.ReadPerSec,
+		WritePerSec: io.WritePerSec,
+	}
+}
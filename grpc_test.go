@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"system-monitor/monitorpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// freePort returns a local TCP port nothing is listening on
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestGRPCStreamMetrics(t *testing.T) {
+	s, _ := newTestServer(t)
+	port := freePort(t)
+	if err := s.startGRPC(port); err != nil {
+		t.Fatal(err)
+	}
+	s.startDataPublisher()
+
+	conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := monitorpb.NewMonitorClient(conn).StreamMetrics(ctx, &monitorpb.StreamMetricsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		snapshot, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if got := snapshot.GetSystem().GetHostname(); got != "test-host" {
+			t.Errorf("frame %d hostname = %q, want test-host", i, got)
+		}
+		if got := snapshot.GetCpu().GetPercentages(); len(got) != 2 || got[1] != 30 {
+			t.Errorf("frame %d CPU percentages = %v, want [10 30]", i, got)
+		}
+		if got := snapshot.GetDisk().GetUsedPercent(); got != 40 {
+			t.Errorf("frame %d disk used = %v, want 40", i, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// ntpPort is used when --ntp-server has no port
+	ntpPort = "123"
+	// ntpEpochOffset is the seconds from the NTP epoch, 1900, to Unix's
+	ntpEpochOffset = 2208988800
+)
+
+// ClockInfo holds the clock synchronization state. Synced is the kernel's
+// view of whether NTP keeps the clock disciplined, nil where the platform
+// doesn't expose it. With an NTP server configured the offset is measured
+// against it, and Error is set when it could not be reached; otherwise the
+// offset is the kernel's own estimate.
+type ClockInfo struct {
+	Synced     *bool    `json:"synced,omitempty"`
+	OffsetMs   *float64 `json:"offsetMs,omitempty"`
+	MaxErrorMs *float64 `json:"maxErrorMs,omitempty"`
+	Server     string   `json:"server,omitempty"`
+	Stratum    int      `json:"stratum,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// GetClockInfo reads the kernel's synchronization state and, when server
+// is set, queries it over SNTP for the offset. It returns nil without an
+// error when neither is available.
+func GetClockInfo(server string, timeout time.Duration) (*ClockInfo, error) {
+	info, err := readKernelClock()
+	if err != nil {
+		return nil, err
+	}
+	if server == "" {
+		return info, nil
+	}
+
+	if info == nil {
+		info = &ClockInfo{}
+	}
+	info.Server = server
+	offset, stratum, err := queryNTP(server, timeout)
+	if err != nil {
+		info.Error = err.Error()
+		info.OffsetMs = nil
+		return info, nil
+	}
+	ms := float64(offset) / float64(time.Millisecond)
+	info.OffsetMs = &ms
+	info.Stratum = stratum
+	return info, nil
+}
+
+// queryNTP asks server for the time with a single SNTP v3 request and
+// returns how far the local clock is behind it (negative when ahead)
+func queryNTP(server string, timeout time.Duration) (time.Duration, int, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, ntpPort)
+	}
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, 0, err
+	}
+
+	// LI 0, version 3, mode 3 (client)
+	request := make([]byte, 48)
+	request[0] = 0x1b
+	sent := time.Now()
+	if _, err := conn.Write(request); err != nil {
+		return 0, 0, err
+	}
+	response := make([]byte, 48)
+	if _, err := conn.Read(response); err != nil {
+		return 0, 0, err
+	}
+	received := time.Now()
+
+	stratum := int(response[1])
+	if stratum == 0 {
+		return 0, 0, fmt.Errorf("server refused the request (kiss code %q)", response[12:16])
+	}
+	if mode := response[0] & 0x7; mode != 4 {
+		return 0, 0, errors.New("malformed NTP response")
+	}
+
+	// The server's receive and transmit times bracket its processing, so
+	// the network delay cancels out of the offset
+	serverReceived := ntpTime(response[32:40])
+	serverSent := ntpTime(response[40:48])
+	offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+	return offset, stratum, nil
+}
+
+// ntpTime decodes a 64-bit NTP timestamp
+func ntpTime(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[:4])) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:]))
+	return time.Unix(seconds, fraction*int64(time.Second)>>32)
+}
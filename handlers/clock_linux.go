@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// adjtimex(2) state and status bits
+const (
+	timeError = 5
+	staUnsync = 0x0040
+	staNano   = 0x2000
+)
+
+// readKernelClock reads the kernel's NTP state with adjtimex, the same
+// source timedatectl's NTPSynchronized reports, whichever daemon (chronyd,
+// ntpd or systemd-timesyncd) disciplines the clock
+func readKernelClock() (*ClockInfo, error) {
+	var timex syscall.Timex
+	state, err := syscall.Adjtimex(&timex)
+	if err != nil {
+		return nil, fmt.Errorf("reading clock state: %w", err)
+	}
+
+	synced := state != timeError && timex.Status&staUnsync == 0
+	unit := 1e-3 // microseconds to milliseconds
+	if timex.Status&staNano != 0 {
+		unit = 1e-6
+	}
+	offset := float64(timex.Offset) * unit
+	// maxerror is always in microseconds
+	maxError := float64(timex.Maxerror) * 1e-3
+	return &ClockInfo{Synced: &synced, OffsetMs: &offset, MaxErrorMs: &maxError}, nil
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package handlers
+
+func readKernelClock() (*ClockInfo, error) {
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CPUSampler measures per-core usage over a fixed window on its own
+// goroutine. Without it each reading covers the gap since the previous
+// collection, so its accuracy depends on the publish cadence.
+type CPUSampler struct {
+	interval time.Duration
+	mu       sync.RWMutex
+	latest   []float64
+	err      error
+}
+
+// NewCPUSampler creates a sampler measuring over interval; call Run to
+// start it
+func NewCPUSampler(interval time.Duration) *CPUSampler {
+	return &CPUSampler{interval: interval}
+}
+
+// Run samples back to back until ctx is cancelled. Each cpu.Percent call
+// blocks for the whole interval, so a cancellation takes effect once the
+// sample in flight completes.
+func (s *CPUSampler) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		percentages, err := cpuPercent(s.interval, true)
+
+		s.mu.Lock()
+		s.err = err
+		if err == nil {
+			s.latest = percentages
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Latest returns the most recent sample, which is empty until the first
+// window has elapsed, along with the error of the latest attempt
+func (s *CPUSampler) Latest() ([]float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.latest, nil
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// customWaitDelay is how long a timed-out command's output pipes may stay
+// open after it is killed, for children that inherited them
+const customWaitDelay = time.Second
+
+// customStderrLimit bounds the stderr kept for display on failure
+const customStderrLimit = 512
+
+// CustomCommand is a --custom-metric: a shell command whose numeric output
+// is shown as a gauge. Interval is zero to collect every --interval.
+type CustomCommand struct {
+	Name     string
+	Command  string
+	Interval time.Duration
+	Unit     string
+}
+
+// CustomMetric is the latest reading of a custom command. Value is nil when
+// the command failed or printed something other than a number, in which
+// case Error says why and Stderr holds the start of its error output.
+type CustomMetric struct {
+	Name   string   `json:"name"`
+	Unit   string   `json:"unit,omitempty"`
+	Value  *float64 `json:"value,omitempty"`
+	Error  string   `json:"error,omitempty"`
+	Stderr string   `json:"stderr,omitempty"`
+}
+
+// RunCustomCommand runs the command through the platform shell, killing it
+// after timeout, and parses the first word of its output as a number. A
+// failing command is reported in the metric rather than as an error, so
+// the panel can show why.
+func RunCustomCommand(command CustomCommand, timeout time.Duration) (CustomMetric, error) {
+	metric := CustomMetric{Name: command.Name, Unit: command.Unit}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	shell := CurrentPlatform().Shell
+	cmd := exec.CommandContext(ctx, shell[0], append(shell[1:], command.Command)...)
+	cmd.WaitDelay = customWaitDelay
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	metric.Stderr = truncateOutput(stderr.String())
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		metric.Error = fmt.Sprintf("timed out after %s", timeout)
+		return metric, nil
+	}
+	if err != nil {
+		metric.Error = err.Error()
+		return metric, nil
+	}
+
+	fields := strings.Fields(stdout.String())
+	if len(fields) == 0 {
+		metric.Error = "no output"
+		return metric, nil
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		metric.Error = fmt.Sprintf("output %q is not a number", truncateOutput(fields[0]))
+		return metric, nil
+	}
+	metric.Value = &value
+	return metric, nil
+}
+
+// truncateOutput trims command output for display
+func truncateOutput(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > customStderrLimit {
+		s = s[:customStderrLimit] + "…"
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// DirInfo holds the total size of a watched directory tree
+type DirInfo struct {
+	Path  string `json:"path"`
+	Bytes uint64 `json:"bytes"`
+	Files int    `json:"files"`
+	// Skipped counts entries that could not be read, e.g. for lack of
+	// permission; their sizes are missing from Bytes
+	Skipped int `json:"skipped"`
+	// Error is set when the directory itself cannot be read
+	Error string `json:"error,omitempty"`
+}
+
+// GetDirSize walks path and sums the sizes of the regular files below it.
+// Unreadable entries are skipped and counted rather than failing the walk.
+// Symlinks are not followed.
+func GetDirSize(path string) (*DirInfo, error) {
+	info := &DirInfo{Path: path}
+
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == path {
+				return err
+			}
+			info.Skipped++
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		fileInfo, err := d.Info()
+		if err != nil {
+			// The file was removed or became unreadable mid-walk
+			info.Skipped++
+			return nil
+		}
+		info.Bytes += uint64(fileInfo.Size())
+		info.Files++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetDirSizes sizes every watched directory. A directory that cannot be
+// read is reported with its error so the others still show.
+func GetDirSizes(paths []string) ([]DirInfo, error) {
+	dirs := make([]DirInfo, 0, len(paths))
+	for _, path := range paths {
+		info, err := GetDirSize(path)
+		if err != nil {
+			dirs = append(dirs, DirInfo{Path: path, Error: err.Error()})
+			continue
+		}
+		dirs = append(dirs, *info)
+	}
+	return dirs, nil
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+// DiskIOInfo holds the bytes the disks have read and written since boot and
+// the rates since the previous reading, zero on the first. Counters sum the
+// whole disks only, as partitions and device-mapper volumes repeat their
+// traffic.
+type DiskIOInfo struct {
+	ReadBytes   uint64  `json:"readBytes,omitempty"`
+	WriteBytes  uint64  `json:"writeBytes,omitempty"`
+	ReadPerSec  float64 `json:"readPerSec,omitempty"`
+	WritePerSec float64 `json:"writePerSec,omitempty"`
+}
+
+// DiskIOTracker computes disk transfer rates from successive counter
+// readings. Rates are taken per disk and summed over the disks present in
+// both readings, so a drive plugged in or pulled between them doesn't show
+// up as a burst or a stall.
+type DiskIOTracker struct {
+	mu       sync.Mutex
+	last     map[string]disk.IOCountersStat
+	lastTime time.Time
+}
+
+// NewDiskIOTracker creates an empty disk I/O tracker
+func NewDiskIOTracker() *DiskIOTracker {
+	return &DiskIOTracker{}
+}
+
+// Read returns the disk I/O since boot and the rates since the previous
+// call. It is nil when the counters cannot be read, so the disk panel
+// leaves the I/O rows out.
+func (t *DiskIOTracker) Read() *DiskIOInfo {
+	counters, err := disk.IOCounters()
+	if err != nil || len(counters) == 0 {
+		return nil
+	}
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elapsed := now.Sub(t.lastTime).Seconds()
+	info := &DiskIOInfo{}
+	disks := make(map[string]disk.IOCountersStat, len(counters))
+	for name, c := range counters {
+		if !isWholeDisk(name) {
+			continue
+		}
+		disks[name] = c
+		info.ReadBytes += c.ReadBytes
+		info.WriteBytes += c.WriteBytes
+		if prev, ok := t.last[name]; ok && elapsed > 0 {
+			info.ReadPerSec += counterRate(prev.ReadBytes, c.ReadBytes, elapsed)
+			info.WritePerSec += counterRate(prev.WriteBytes, c.WriteBytes, elapsed)
+		}
+	}
+	t.last = disks
+	t.lastTime = now
+	return info
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// isWholeDisk reports whether the block device is backed by hardware:
+// partitions have no /sys/block entry, and loop, RAM and device-mapper
+// devices have no device link
+func isWholeDisk(name string) bool {
+	_, err := os.Stat(filepath.Join("/sys/block", name, "device"))
+	return err == nil
+}
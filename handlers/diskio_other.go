@@ -0,0 +1,9 @@
+//go:build !linux
+
+package handlers
+
+// isWholeDisk keeps every device; other platforms report one entry per
+// physical disk
+func isWholeDisk(string) bool {
+	return true
+}
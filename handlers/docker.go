@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// dockerTimeout bounds each collection so a hung daemon cannot stall the
+// collector
+const dockerTimeout = 5 * time.Second
+
+// ContainerStat holds resource usage for a single running container
+type ContainerStat struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpuPercent"`
+	MemUsage   uint64  `json:"memUsage"`
+	MemLimit   uint64  `json:"memLimit"`
+	MemPercent float64 `json:"memPercent"`
+}
+
+// containerCPU is the CPU counter pair needed to compute usage between ticks
+type containerCPU struct {
+	total  uint64
+	system uint64
+}
+
+// ContainerTracker queries the Docker daemon and keeps each container's CPU
+// counters between ticks, since one-shot stats carry no previous reading
+type ContainerTracker struct {
+	client *client.Client
+
+	mu   sync.Mutex
+	last map[string]containerCPU
+}
+
+// NewContainerTracker creates a tracker for the daemon configured by the
+// DOCKER_HOST environment (the local socket by default)
+func NewContainerTracker() (*ContainerTracker, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContainerTracker{
+		client: cli,
+		last:   make(map[string]containerCPU),
+	}, nil
+}
+
+// GetContainerStats retrieves CPU and memory usage for every running
+// container. When the daemon cannot be reached it returns no containers and
+// a nil error, so hosts without Docker just show an empty table.
+func (t *ContainerTracker) GetContainerStats() ([]ContainerStat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dockerTimeout)
+	defer cancel()
+
+	containers, err := t.client.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		if client.IsErrConnectionFailed(err) {
+			return []ContainerStat{}, nil
+		}
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := make(map[string]containerCPU, len(containers))
+	stats := make([]ContainerStat, 0, len(containers))
+
+	for _, c := range containers {
+		resp, err := t.client.ContainerStatsOneShot(ctx, c.ID)
+		if err != nil {
+			// The container stopped between listing and inspection
+			continue
+		}
+
+		var raw container.StatsResponse
+		err = json.NewDecoder(resp.Body).Decode(&raw)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+
+		cpu := containerCPU{
+			total:  raw.CPUStats.CPUUsage.TotalUsage,
+			system: raw.CPUStats.SystemUsage,
+		}
+		current[c.ID] = cpu
+
+		stat := ContainerStat{
+			ID:       shortContainerID(c.ID),
+			Name:     containerName(c.Names, c.ID),
+			MemUsage: containerMemUsage(raw.MemoryStats, resp.OSType),
+			MemLimit: raw.MemoryStats.Limit,
+		}
+		if prev, ok := t.last[c.ID]; ok {
+			stat.CPUPercent = containerCPUPercent(prev, cpu, raw.CPUStats.OnlineCPUs)
+		}
+		if stat.MemLimit > 0 {
+			stat.MemPercent = 100 * float64(stat.MemUsage) / float64(stat.MemLimit)
+		}
+		stats = append(stats, stat)
+	}
+	t.last = current
+
+	return stats, nil
+}
+
+// containerCPUPercent computes usage the way `docker stats` does: the
+// container's share of host CPU time since the previous reading, scaled by
+// the number of online CPUs
+func containerCPUPercent(prev, cur containerCPU, onlineCPUs uint32) float64 {
+	if cur.total < prev.total || cur.system <= prev.system {
+		return 0
+	}
+	if onlineCPUs == 0 {
+		onlineCPUs = 1
+	}
+	cpuDelta := float64(cur.total - prev.total)
+	systemDelta := float64(cur.system - prev.system)
+	return cpuDelta / systemDelta * float64(onlineCPUs) * 100
+}
+
+// containerMemUsage excludes reclaimable page cache on Linux, matching
+// `docker stats`; Windows reports the private working set
+func containerMemUsage(mem container.MemoryStats, osType string) uint64 {
+	if osType == "windows" {
+		return mem.PrivateWorkingSet
+	}
+
+	// cgroup v1 reports total_inactive_file, cgroup v2 inactive_file
+	inactive, ok := mem.Stats["total_inactive_file"]
+	if !ok {
+		inactive = mem.Stats["inactive_file"]
+	}
+	if inactive > mem.Usage {
+		return mem.Usage
+	}
+	return mem.Usage - inactive
+}
+
+func containerName(names []string, id string) string {
+	if len(names) == 0 {
+		return shortContainerID(id)
+	}
+	return strings.TrimPrefix(names[0], "/")
+}
+
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
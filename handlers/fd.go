@@ -0,0 +1,36 @@
+package handlers
+
+// FDInfo holds file descriptor usage: the monitor's own open descriptors
+// against its RLIMIT_NOFILE, and the kernel's system-wide file handles.
+// The system fields are nil where the kernel doesn't report them.
+type FDInfo struct {
+	Open       uint64  `json:"open"`
+	SoftLimit  uint64  `json:"softLimit"`
+	HardLimit  uint64  `json:"hardLimit"`
+	SystemOpen *uint64 `json:"systemOpen,omitempty"`
+	SystemMax  *uint64 `json:"systemMax,omitempty"`
+}
+
+// ProcessPercent returns the open descriptors as a percentage of the soft
+// limit, which is the one open(2) fails at
+func (f *FDInfo) ProcessPercent() float64 {
+	if f.SoftLimit == 0 {
+		return 0
+	}
+	return float64(f.Open) / float64(f.SoftLimit) * 100
+}
+
+// SystemPercent returns the system-wide handles as a percentage of the
+// kernel maximum, or false when either is unknown
+func (f *FDInfo) SystemPercent() (float64, bool) {
+	if f.SystemOpen == nil || f.SystemMax == nil || *f.SystemMax == 0 {
+		return 0, false
+	}
+	return float64(*f.SystemOpen) / float64(*f.SystemMax) * 100, true
+}
+
+// GetFDInfo reads file descriptor usage. It returns nil without an error
+// on platforms where it isn't supported.
+func GetFDInfo() (*FDInfo, error) {
+	return readFDInfo()
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	selfFDDir = "/proc/self/fd"
+	fileNr    = "/proc/sys/fs/file-nr"
+)
+
+func readFDInfo() (*FDInfo, error) {
+	entries, err := os.ReadDir(selfFDDir)
+	if err != nil {
+		return nil, err
+	}
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return nil, fmt.Errorf("reading RLIMIT_NOFILE: %w", err)
+	}
+	info := &FDInfo{
+		// The directory handle open while reading is one of the entries
+		Open:      uint64(max(len(entries)-1, 0)),
+		SoftLimit: limit.Cur,
+		HardLimit: limit.Max,
+	}
+
+	// Containers without a full /proc still report the process figures
+	data, err := os.ReadFile(fileNr)
+	if err != nil {
+		return info, nil
+	}
+	if info.SystemOpen, info.SystemMax, err = parseFileNr(string(data)); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", fileNr, err)
+	}
+	return info, nil
+}
+
+// parseFileNr parses /proc/sys/fs/file-nr, "allocated unused max". Kernels
+// since 2.6 always report 0 unused, but it is subtracted all the same.
+func parseFileNr(data string) (open, limit *uint64, err error) {
+	fields := strings.Fields(data)
+	if len(fields) != 3 {
+		return nil, nil, fmt.Errorf("malformed line %q", strings.TrimSpace(data))
+	}
+	var values [3]uint64
+	for i, field := range fields {
+		if values[i], err = strconv.ParseUint(field, 10, 64); err != nil {
+			return nil, nil, fmt.Errorf("malformed field %q", field)
+		}
+	}
+	allocated := values[0] - min(values[1], values[0])
+	return &allocated, &values[2], nil
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package handlers
+
+func readFDInfo() (*FDInfo, error) {
+	return nil, nil
+}
@@ -1,92 +1,173 @@
 package handlers
 
 import (
+	"errors"
 	"runtime"
+	"time"
 
 	"github.com/shirou/gopsutil/v4/cpu"
-	"github.com/shirou/gopsutil/v4/disk"
 	"github.com/shirou/gopsutil/v4/host"
 	"github.com/shirou/gopsutil/v4/mem"
 )
 
-const megabyteDiv uint64 = 1024 * 1024
-const gigabyteDiv uint64 = megabyteDiv * 1024
+// mebibyteDiv converts byte counts to the MiB memory sizes are reported in
+const mebibyteDiv uint64 = 1024 * 1024
 
-// SystemInfo holds system information
+// cpuRetryInterval is how long GetCPUInfo blocks to measure usage when the
+// non-blocking call has no previous reading to compare against
+const cpuRetryInterval = 200 * time.Millisecond
+
+// cpuPercent is swapped out to exercise the empty-result retry
+var cpuPercent = cpu.Percent
+
+// SystemInfo holds system information. Memory sizes are in MiB.
 type SystemInfo struct {
-	OS           string
-	Platform     string
-	Hostname     string
-	Procs        uint64
-	TotalMem     uint64
-	FreeMem      uint64
-	UsedPercent  float64
+	OS          string  `json:"os"`
+	Platform    string  `json:"platform"`
+	Hostname    string  `json:"hostname"`
+	Procs       uint64  `json:"procs"`
+	TotalMem    uint64  `json:"totalMem"`
+	FreeMem     uint64  `json:"freeMem"`
+	UsedPercent float64 `json:"usedPercent"`
+	// Unavailable names the metrics that could not be read, using the
+	// Metric* constants; their fields are left zero
+	Unavailable []string `json:"unavailable,omitempty"`
 }
 
-// DiskInfo holds disk information
+// SystemInfo metrics that can be reported as unavailable
+const (
+	MetricHostname  = "hostname"
+	MetricPlatform  = "platform"
+	MetricProcesses = "processes"
+	MetricMemory    = "memory"
+)
+
+// DiskInfo holds disk information for the primary disk at Path along with
+// every mounted filesystem. Sizes are in bytes; UsedPercent is the
+// authoritative usage figure. IO is nil where the disks' traffic can't be
+// read, as over SNMP.
 type DiskInfo struct {
-	Total       uint64
-	Used        uint64
-	Free        uint64
-	UsedPercent float64
+	Path        string      `json:"path,omitempty"`
+	Total       uint64      `json:"total"`
+	Used        uint64      `json:"used"`
+	Free        uint64      `json:"free"`
+	UsedPercent float64     `json:"usedPercent"`
+	Mounts      []MountInfo `json:"mounts"`
+	IO          *DiskIOInfo `json:"io,omitempty"`
+	// Listed is every mount the OS lists, including those left out of
+	// Mounts because their usage could not be read, which carry no usage.
+	// Sources that cannot tell leave it nil.
+	Listed []MountInfo `json:"-"`
 }
 
-// CPUInfo holds CPU information
+// CPUInfo holds CPU information. Percentages is empty while usage is still
+// being measured. Steal is the percentage of time the hypervisor gave to
+// other guests, nil where it isn't reported.
 type CPUInfo struct {
-	ModelName    string
-	Family       string
-	Mhz          float64
-	Percentages  []float64
+	ModelName   string    `json:"modelName"`
+	Family      string    `json:"family"`
+	Mhz         float64   `json:"mhz"`
+	Percentages []float64 `json:"percentages"`
+	// Average is only set in API responses that ask for it with ?cpu=
+	Average *float64 `json:"average,omitempty"`
+	Steal   *float64 `json:"steal,omitempty"`
+}
+
+// OverallUsage averages the per-core percentages into one figure, zero
+// before the first measurement
+func (c *CPUInfo) OverallUsage() float64 {
+	if len(c.Percentages) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, p := range c.Percentages {
+		sum += p
+	}
+	return sum / float64(len(c.Percentages))
 }
 
-// GetSystemInfo retrieves system information
+// GetSystemInfo retrieves system information. Host and memory details are
+// read independently, so when one is restricted (as on locked-down hosts)
+// the other is still returned and the missing fields are marked
+// unavailable. It only fails when neither can be read.
 func GetSystemInfo() (*SystemInfo, error) {
-	runTimeOS := runtime.GOOS
+	info := &SystemInfo{
+		OS: runtime.GOOS,
+	}
 
-	vmStat, err := mem.VirtualMemory()
-	if err != nil {
-		return nil, err
+	vmStat, memErr := mem.VirtualMemory()
+	if memErr == nil {
+		info.TotalMem = vmStat.Total / mebibyteDiv
+		info.FreeMem = vmStat.Free / mebibyteDiv
+		info.UsedPercent = sanitizePercent(MetricMemory, vmStat.UsedPercent)
+	} else {
+		info.Unavailable = append(info.Unavailable, MetricMemory)
 	}
 
-	hostStat, err := host.Info()
-	if err != nil {
-		return nil, err
+	// host.Info can return partial data alongside an error
+	hostStat, hostErr := host.Info()
+	if hostStat != nil {
+		info.Platform = hostStat.Platform
+		info.Hostname = hostStat.Hostname
+		info.Procs = hostStat.Procs
+	}
+	if info.Hostname == "" {
+		info.Unavailable = append(info.Unavailable, MetricHostname)
+	}
+	if info.Platform == "" {
+		info.Unavailable = append(info.Unavailable, MetricPlatform)
+	}
+	if info.Procs == 0 {
+		info.Unavailable = append(info.Unavailable, MetricProcesses)
 	}
 
-	return &SystemInfo{
-		OS:          runTimeOS,
-		Platform:    hostStat.Platform,
-		Hostname:    hostStat.Hostname,
-		Procs:       hostStat.Procs,
-		TotalMem:    vmStat.Total / megabyteDiv,
-		FreeMem:     vmStat.Free / megabyteDiv,
-		UsedPercent: vmStat.UsedPercent,
-	}, nil
+	if memErr != nil && hostErr != nil {
+		return nil, errors.Join(memErr, hostErr)
+	}
+	return info, nil
 }
 
-// GetDiskInfo retrieves disk information
-func GetDiskInfo() (*DiskInfo, error) {
-	diskStat, err := disk.Usage("/")
+// GetDiskInfo retrieves the usage of path, or of the primary disk when path
+// is empty, along with every mount, reading each filesystem's usage once
+func GetDiskInfo(path string) (*DiskInfo, error) {
+	cache := make(usageCache)
+	mounts, listed, err := getMountInfo(cache)
+	if err != nil {
+		return nil, err
+	}
+	path = primaryDisk(path, mounts, CurrentPlatform().DiskPath)
+
+	diskStat, err := cache.usage(path)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DiskInfo{
-		Total:       diskStat.Total / gigabyteDiv,
-		Used:        diskStat.Used / gigabyteDiv,
-		Free:        diskStat.Free / gigabyteDiv,
-		UsedPercent: diskStat.UsedPercent,
+		Path:        path,
+		Total:       diskStat.Total,
+		Used:        diskStat.Used,
+		Free:        diskStat.Free,
+		UsedPercent: sanitizePercent("disk", diskStat.UsedPercent),
+		Mounts:      mounts,
+		Listed:      listed,
 	}, nil
 }
 
-// GetCPUInfo retrieves CPU information
+// GetCPUInfo retrieves CPU information, measuring usage since the previous
+// call
 func GetCPUInfo() (*CPUInfo, error) {
+	return getCPUInfo(getCPUPercentages)
+}
+
+// getCPUInfo retrieves CPU information with usage from percentages
+func getCPUInfo(percentages func() ([]float64, error)) (*CPUInfo, error) {
 	cpuStat, err := cpu.Info()
 	if err != nil {
 		return nil, err
 	}
 
-	percentage, err := cpu.Percent(0, true)
+	percentage, err := percentages()
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +185,21 @@ func GetCPUInfo() (*CPUInfo, error) {
 		ModelName:   modelName,
 		Family:      family,
 		Mhz:         mhz,
-		Percentages: percentage,
+		Percentages: sanitizePercentages("cpu", percentage),
 	}, nil
 }
+
+// getCPUPercentages returns per-core usage. Some VMs return no values from
+// the first non-blocking call, so it retries once with a short blocking
+// measurement; a still-empty result is passed on for the "collecting" state.
+func getCPUPercentages() ([]float64, error) {
+	percentage, err := cpuPercent(0, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(percentage) > 0 {
+		return percentage, nil
+	}
+
+	return cpuPercent(cpuRetryInterval, true)
+}
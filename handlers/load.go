@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"runtime"
+
+	"github.com/shirou/gopsutil/v4/load"
+)
+
+// LoadInfo holds load average information. CPUs is the number of logical
+// CPUs the averages are normalized by; it is zero in snapshots from
+// monitors that predate it.
+type LoadInfo struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+	CPUs   int     `json:"cpus,omitempty"`
+}
+
+// PerCore returns a load average divided by the CPU count, where 1 means
+// every core is busy, or false when the count is unknown
+func (l *LoadInfo) PerCore(load float64) (float64, bool) {
+	if l.CPUs <= 0 {
+		return 0, false
+	}
+	return load / float64(l.CPUs), true
+}
+
+// GetLoadInfo retrieves the 1, 5 and 15 minute load averages
+func GetLoadInfo() (*LoadInfo, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadInfo{
+		Load1:  avg.Load1,
+		Load5:  avg.Load5,
+		Load15: avg.Load15,
+		CPUs:   runtime.NumCPU(),
+	}, nil
+}
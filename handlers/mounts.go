@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"slices"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+// MountInfo holds usage in bytes and mount state for a single mounted
+// filesystem
+type MountInfo struct {
+	Mountpoint  string  `json:"mountpoint"`
+	Device      string  `json:"device"`
+	Fstype      string  `json:"fstype"`
+	ReadOnly    bool    `json:"readOnly"`
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	Free        uint64  `json:"free"`
+	UsedPercent float64 `json:"usedPercent"`
+}
+
+// usageCache memoizes disk.Usage for one disk collection, so the primary
+// disk is not queried again when it is also among the mounts. A new cache
+// is made for every collection, keeping each tick's figures fresh.
+type usageCache map[string]usageResult
+
+type usageResult struct {
+	stat *disk.UsageStat
+	err  error
+}
+
+func (c usageCache) usage(path string) (*disk.UsageStat, error) {
+	if r, ok := c[path]; ok {
+		return r.stat, r.err
+	}
+	stat, err := disk.Usage(path)
+	c[path] = usageResult{stat, err}
+	return stat, err
+}
+
+// getMountInfo lists the physical mounts with their usage. A mount whose
+// usage cannot be read (e.g. a stale network share) is skipped rather than
+// failing the whole disk collection; listed still includes it, without
+// usage.
+func getMountInfo(cache usageCache) (mounts, listed []MountInfo, err error) {
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mounts = make([]MountInfo, 0, len(partitions))
+	listed = make([]MountInfo, 0, len(partitions))
+	seen := make(map[string]struct{}, len(partitions))
+
+	for _, p := range partitions {
+		if _, ok := seen[p.Mountpoint]; ok {
+			continue
+		}
+		seen[p.Mountpoint] = struct{}{}
+
+		mount := MountInfo{
+			Mountpoint: p.Mountpoint,
+			Device:     p.Device,
+			Fstype:     p.Fstype,
+			ReadOnly:   slices.Contains(p.Opts, "ro"),
+		}
+		listed = append(listed, mount)
+
+		usage, err := cache.usage(p.Mountpoint)
+		if err != nil {
+			continue
+		}
+
+		mount.Total = usage.Total
+		mount.Used = usage.Used
+		mount.Free = usage.Free
+		mount.UsedPercent = sanitizePercent("disk", usage.UsedPercent)
+		mounts = append(mounts, mount)
+	}
+
+	slices.SortFunc(mounts, func(a, b MountInfo) int {
+		if a.Mountpoint < b.Mountpoint {
+			return -1
+		}
+		if a.Mountpoint > b.Mountpoint {
+			return 1
+		}
+		return 0
+	})
+
+	return mounts, listed, nil
+}
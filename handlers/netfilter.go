@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// InterfaceFilter selects the network interfaces reported, from glob
+// patterns such as "eth*". Patterns starting with "!" exclude matching
+// interfaces; when there are include patterns, an interface must match one
+// of them. The zero value keeps every interface.
+type InterfaceFilter struct {
+	include []string
+	exclude []string
+}
+
+// ParseInterfaceFilter builds a filter from include and "!"-prefixed
+// exclude patterns
+func ParseInterfaceFilter(patterns []string) (InterfaceFilter, error) {
+	var f InterfaceFilter
+	for _, pattern := range patterns {
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		if pattern == "" {
+			return InterfaceFilter{}, fmt.Errorf("empty interface pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return InterfaceFilter{}, fmt.Errorf("interface pattern %q: %w", pattern, err)
+		}
+		if exclude {
+			f.exclude = append(f.exclude, pattern)
+		} else {
+			f.include = append(f.include, pattern)
+		}
+	}
+	return f, nil
+}
+
+// Match reports whether the interface is kept
+func (f InterfaceFilter) Match(name string) bool {
+	if matchAny(f.exclude, name) {
+		return false
+	}
+	return len(f.include) == 0 || matchAny(f.include, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		// Patterns were validated when the filter was parsed
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+// NetInterfaceInfo holds the counters since boot and the transfer rates for
+// a network interface. Zero values are left out of the JSON, which is how
+// /api/metrics?counters= drops the half not asked for.
+type NetInterfaceInfo struct {
+	Name       string  `json:"name"`
+	BytesSent  uint64  `json:"bytesSent,omitempty"`
+	BytesRecv  uint64  `json:"bytesRecv,omitempty"`
+	SentPerSec float64 `json:"sentPerSec,omitempty"`
+	RecvPerSec float64 `json:"recvPerSec,omitempty"`
+}
+
+// NetworkInfo holds network information. Counters cover IPv4 and IPv6
+// traffic alike, as the kernel keeps them per interface.
+type NetworkInfo struct {
+	Interfaces []NetInterfaceInfo `json:"interfaces"`
+	// Total sums every listed non-loopback interface from the same snapshot
+	Total NetInterfaceInfo `json:"total"`
+}
+
+// NetRateTracker computes transfer rates from successive counter snapshots
+type NetRateTracker struct {
+	mu       sync.Mutex
+	last     map[string]net.IOCountersStat
+	lastTime time.Time
+}
+
+// NewNetRateTracker creates an empty rate tracker
+func NewNetRateTracker() *NetRateTracker {
+	return &NetRateTracker{
+		last: make(map[string]net.IOCountersStat),
+	}
+}
+
+// GetNetworkInfo retrieves counters and rates for the interfaces the filter
+// keeps. Rates are tracked for every interface, so one added to the filter
+// by a reload has a rate from its first reading.
+func (t *NetRateTracker) GetNetworkInfo(filter InterfaceFilter) (*NetworkInfo, error) {
+	counters, err := net.IOCounters(true)
+	if err != nil {
+		return nil, err
+	}
+
+	loopback := loopbackInterfaces()
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elapsed := now.Sub(t.lastTime).Seconds()
+	info := &NetworkInfo{
+		Total: NetInterfaceInfo{Name: "total"},
+	}
+	current := make(map[string]net.IOCountersStat, len(counters))
+
+	for _, c := range counters {
+		current[c.Name] = c
+		if !filter.Match(c.Name) {
+			continue
+		}
+
+		iface := NetInterfaceInfo{
+			Name:      c.Name,
+			BytesSent: c.BytesSent,
+			BytesRecv: c.BytesRecv,
+		}
+		if prev, ok := t.last[c.Name]; ok && elapsed > 0 {
+			iface.SentPerSec = counterRate(prev.BytesSent, c.BytesSent, elapsed)
+			iface.RecvPerSec = counterRate(prev.BytesRecv, c.BytesRecv, elapsed)
+		}
+		info.Interfaces = append(info.Interfaces, iface)
+
+		if _, ok := loopback[c.Name]; ok {
+			continue
+		}
+		info.Total.BytesSent += iface.BytesSent
+		info.Total.BytesRecv += iface.BytesRecv
+		info.Total.SentPerSec += iface.SentPerSec
+		info.Total.RecvPerSec += iface.RecvPerSec
+	}
+
+	t.last = current
+	t.lastTime = now
+
+	return info, nil
+}
+
+// counterRate returns the per-second rate between two cumulative counter
+// readings, treating a counter that went backwards as a reset
+func counterRate(prev, cur uint64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
+// loopbackInterfaces returns the names of interfaces flagged as loopback
+func loopbackInterfaces() map[string]struct{} {
+	names := make(map[string]struct{})
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		names["lo"] = struct{}{}
+		return names
+	}
+
+	for _, iface := range ifaces {
+		for _, flag := range iface.Flags {
+			if flag == "loopback" {
+				names[iface.Name] = struct{}{}
+				break
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,21 @@
+package handlers
+
+// NUMANodeInfo holds memory information for a single NUMA node, in MiB
+type NUMANodeInfo struct {
+	Node        int     `json:"node"`
+	TotalMem    uint64  `json:"totalMem"`
+	FreeMem     uint64  `json:"freeMem"`
+	UsedMem     uint64  `json:"usedMem"`
+	UsedPercent float64 `json:"usedPercent"`
+}
+
+// GetNUMAInfo retrieves per-node memory information. It returns nil without
+// an error on platforms without NUMA support and on single-node systems,
+// where the aggregate memory figures already tell the whole story.
+func GetNUMAInfo() ([]NUMANodeInfo, error) {
+	nodes, err := readNUMANodes()
+	if err != nil || len(nodes) < 2 {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const numaNodeGlob = "/sys/devices/system/node/node*/meminfo"
+
+func readNUMANodes() ([]NUMANodeInfo, error) {
+	paths, err := filepath.Glob(numaNodeGlob)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+
+	nodes := make([]NUMANodeInfo, 0, len(paths))
+	for _, path := range paths {
+		node, err := readNUMANodeMeminfo(path)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Node < nodes[j].Node })
+	return nodes, nil
+}
+
+// readNUMANodeMeminfo parses a node meminfo file, whose lines look like
+// "Node 0 MemTotal:       16318480 kB"
+func readNUMANodeMeminfo(path string) (NUMANodeInfo, error) {
+	var node NUMANodeInfo
+
+	id := strings.TrimPrefix(filepath.Base(filepath.Dir(path)), "node")
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return node, err
+	}
+	node.Node = n
+
+	f, err := os.Open(path)
+	if err != nil {
+		return node, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		mb := kb * 1024 / mebibyteDiv
+
+		switch fields[2] {
+		case "MemTotal:":
+			node.TotalMem = mb
+		case "MemFree:":
+			node.FreeMem = mb
+		case "MemUsed:":
+			node.UsedMem = mb
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return node, err
+	}
+
+	if node.TotalMem > 0 {
+		node.UsedPercent = 100 * float64(node.UsedMem) / float64(node.TotalMem)
+	}
+	return node, nil
+}
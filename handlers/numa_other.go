@@ -0,0 +1,7 @@
+//go:build !linux
+
+package handlers
+
+func readNUMANodes() ([]NUMANodeInfo, error) {
+	return nil, nil
+}
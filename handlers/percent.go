@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"math"
+	"sync"
+
+	"system-monitor/console"
+)
+
+// sanitizedMetrics records the metrics already reported by sanitizePercent,
+// so a host that always reports a zero total logs it once
+var sanitizedMetrics sync.Map
+
+// sanitizePercent clamps a usage percentage to [0, 100]. Some containers and
+// VMs report a zero memory or disk total, making the percentage NaN or Inf,
+// which renders badly and cannot be encoded as JSON; those read as 0.
+func sanitizePercent(metric string, v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		if _, logged := sanitizedMetrics.LoadOrStore(metric, true); !logged {
+			console.Printf(console.Warning, "%s usage is %v, likely from a zero total; reporting 0%%", metric, v)
+		}
+		return 0
+	}
+	return min(max(v, 0), 100)
+}
+
+// sanitizePercentages clamps each value in place, as for sanitizePercent
+func sanitizePercentages(metric string, values []float64) []float64 {
+	for i, v := range values {
+		values[i] = sanitizePercent(metric, v)
+	}
+	return values
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSanitizePercent(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  float64
+	}{
+		{"NaN", math.NaN(), 0},
+		{"+Inf", math.Inf(1), 0},
+		{"-Inf", math.Inf(-1), 0},
+		{"negative", -3, 0},
+		{"zero", 0, 0},
+		{"in range", 42.5, 42.5},
+		{"full", 100, 100},
+		{"above 100", 100.4, 100},
+		{"far above 100", 250, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizePercent("test", tt.value); got != tt.want {
+				t.Errorf("sanitizePercent(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizePercentages(t *testing.T) {
+	got := sanitizePercentages("test", []float64{math.NaN(), 12, math.Inf(1), 130, -1})
+	if want := []float64{0, 12, 0, 100, 0}; !slices.Equal(got, want) {
+		t.Errorf("sanitizePercentages = %v, want %v", got, want)
+	}
+}
+
+func TestZeroTotalsEncode(t *testing.T) {
+	var used, total float64
+	zeroTotal := 100 * used / total // NaN, as from a host reporting no memory or disk
+	if _, err := json.Marshal(MountInfo{UsedPercent: zeroTotal}); err == nil {
+		t.Fatal("encoding a NaN percentage succeeded; the test no longer covers the failure")
+	}
+
+	system := SystemInfo{TotalMem: 0, FreeMem: 0, UsedPercent: sanitizePercent(MetricMemory, zeroTotal)}
+	if _, err := json.Marshal(system); err != nil {
+		t.Errorf("encoding SystemInfo with a zero total: %v", err)
+	}
+	mount := MountInfo{Mountpoint: "/", UsedPercent: sanitizePercent("disk", zeroTotal)}
+	if _, err := json.Marshal(mount); err != nil {
+		t.Errorf("encoding MountInfo with a zero total: %v", err)
+	}
+	disk := DiskInfo{Mounts: []MountInfo{mount}, UsedPercent: sanitizePercent("disk", math.Inf(1))}
+	if _, err := json.Marshal(disk); err != nil {
+		t.Errorf("encoding DiskInfo with a zero total: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"os"
+	"runtime"
+)
+
+// Platform holds the platform-specific defaults used by the collectors and
+// the dashboard, so GOOS checks live in one place
+type Platform struct {
+	// DiskPath is the filesystem backing the OS, which the disk panel
+	// reports unless it is virtual or --disk-path overrides it
+	DiskPath string
+	// HasLoadAvg reports whether load averages are meaningful
+	HasLoadAvg bool
+	// Shell runs a --custom-metric command line when the line is appended
+	Shell []string
+	// HasPagingCounters reports whether gopsutil fills in the swap Sin and
+	// Sout counters the paging rates come from
+	HasPagingCounters bool
+	// HasStealCounter reports whether gopsutil fills in the CPU steal time
+	HasStealCounter bool
+	// HasBlockIOCounters reports whether gopsutil fills in the per-process
+	// bytes that reached the block layer, rather than only everything read
+	// and written including the page cache
+	HasBlockIOCounters bool
+}
+
+// CurrentPlatform returns the defaults for the platform the binary runs on
+func CurrentPlatform() Platform {
+	return platformFor(runtime.GOOS)
+}
+
+func platformFor(goos string) Platform {
+	p := Platform{
+		DiskPath:   "/",
+		HasLoadAvg: true,
+		Shell:      []string{"sh", "-c"},
+	}
+	switch goos {
+	case "windows":
+		drive := os.Getenv("SystemDrive")
+		if drive == "" {
+			drive = "C:"
+		}
+		p.DiskPath = drive + `\`
+		p.HasLoadAvg = false
+		p.Shell = []string{"cmd", "/C"}
+	case "linux":
+		p.HasPagingCounters = true
+		p.HasStealCounter = true
+		p.HasBlockIOCounters = true
+	case "aix":
+		p.HasPagingCounters = true
+	}
+	return p
+}
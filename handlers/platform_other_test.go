@@ -0,0 +1,18 @@
+//go:build !windows
+
+package handlers
+
+import "testing"
+
+func TestCurrentPlatform(t *testing.T) {
+	p := CurrentPlatform()
+	if p.DiskPath != "/" {
+		t.Errorf("DiskPath = %q, want /", p.DiskPath)
+	}
+	if !p.HasLoadAvg {
+		t.Error("HasLoadAvg is unset, but Unix-like systems have load averages")
+	}
+	if len(p.Shell) == 0 || p.Shell[0] != "sh" {
+		t.Errorf("Shell = %q, want sh", p.Shell)
+	}
+}
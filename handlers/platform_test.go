@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlatformFor(t *testing.T) {
+	tests := []struct {
+		goos        string
+		systemDrive string
+		want        Platform
+	}{
+		{"linux", "", Platform{DiskPath: "/", HasLoadAvg: true, Shell: []string{"sh", "-c"}, HasPagingCounters: true, HasStealCounter: true, HasBlockIOCounters: true}},
+		{"aix", "", Platform{DiskPath: "/", HasLoadAvg: true, Shell: []string{"sh", "-c"}, HasPagingCounters: true}},
+		{"darwin", "", Platform{DiskPath: "/", HasLoadAvg: true, Shell: []string{"sh", "-c"}}},
+		{"windows", "", Platform{DiskPath: `C:\`, HasLoadAvg: false, Shell: []string{"cmd", "/C"}}},
+		{"windows", "D:", Platform{DiskPath: `D:\`, HasLoadAvg: false, Shell: []string{"cmd", "/C"}}},
+		{"freebsd", "", Platform{DiskPath: "/", HasLoadAvg: true, Shell: []string{"sh", "-c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+tt.systemDrive, func(t *testing.T) {
+			t.Setenv("SystemDrive", tt.systemDrive)
+			got := platformFor(tt.goos)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("platformFor(%q) = %+v, want %+v", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
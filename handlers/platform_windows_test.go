@@ -0,0 +1,25 @@
+//go:build windows
+
+package handlers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCurrentPlatform(t *testing.T) {
+	p := CurrentPlatform()
+	drive := os.Getenv("SystemDrive")
+	if drive == "" {
+		drive = "C:"
+	}
+	if p.DiskPath != drive+`\` {
+		t.Errorf("DiskPath = %q, want the system drive %s\\", p.DiskPath, drive)
+	}
+	if p.HasLoadAvg {
+		t.Error("HasLoadAvg is set, but Windows has no load averages")
+	}
+	if len(p.Shell) == 0 || p.Shell[0] != "cmd" {
+		t.Errorf("Shell = %q, want cmd", p.Shell)
+	}
+}
@@ -0,0 +1,30 @@
+package handlers
+
+// PressureStall holds the share of wall time, in percent, that tasks were
+// stalled on a resource, averaged over 10, 60 and 300 seconds
+type PressureStall struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+}
+
+// ResourcePressure holds the "some" line (at least one task stalled) and
+// the "full" line (all non-idle tasks stalled) for one resource. Full is
+// nil for CPU on kernels that only report "some".
+type ResourcePressure struct {
+	Some PressureStall  `json:"some"`
+	Full *PressureStall `json:"full,omitempty"`
+}
+
+// PressureInfo holds Linux Pressure Stall Information
+type PressureInfo struct {
+	CPU    ResourcePressure `json:"cpu"`
+	Memory ResourcePressure `json:"memory"`
+	IO     ResourcePressure `json:"io"`
+}
+
+// GetPressureInfo reads CPU, memory and I/O pressure. It returns nil
+// without an error on platforms and kernels without PSI.
+func GetPressureInfo() (*PressureInfo, error) {
+	return readPressure()
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const pressureDir = "/proc/pressure/"
+
+func readPressure() (*PressureInfo, error) {
+	info := &PressureInfo{}
+	for _, r := range []struct {
+		name string
+		dst  *ResourcePressure
+	}{
+		{"cpu", &info.CPU},
+		{"memory", &info.Memory},
+		{"io", &info.IO},
+	} {
+		data, err := os.ReadFile(pressureDir + r.name)
+		if err != nil {
+			// Kernels built without PSI, or booted with psi=0, have no
+			// files or refuse to read them
+			if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.EOPNOTSUPP) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if *r.dst, err = parsePressure(string(data)); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", pressureDir+r.name, err)
+		}
+	}
+	return info, nil
+}
+
+// parsePressure parses a PSI file, whose lines look like
+// "some avg10=0.12 avg60=0.05 avg300=0.01 total=123456"
+func parsePressure(data string) (ResourcePressure, error) {
+	var pressure ResourcePressure
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var stall PressureStall
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				return pressure, fmt.Errorf("malformed field %q", field)
+			}
+			var dst *float64
+			switch key {
+			case "avg10":
+				dst = &stall.Avg10
+			case "avg60":
+				dst = &stall.Avg60
+			case "avg300":
+				dst = &stall.Avg300
+			default:
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return pressure, fmt.Errorf("malformed field %q", field)
+			}
+			*dst = v
+		}
+
+		switch fields[0] {
+		case "some":
+			pressure.Some = stall
+		case "full":
+			pressure.Full = &stall
+		}
+	}
+	return pressure, nil
+}
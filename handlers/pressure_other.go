@@ -0,0 +1,7 @@
+//go:build !linux
+
+package handlers
+
+func readPressure() (*PressureInfo, error) {
+	return nil, nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"slices"
+	"strings"
+)
+
+// virtualRootTypes are filesystems a root can be mounted from that say
+// nothing about a disk: container layers, live images and RAM disks
+var virtualRootTypes = []string{"overlay", "aufs", "composefs", "squashfs", "tmpfs", "ramfs", "rootfs"}
+
+// primaryDisk picks the filesystem the disk panel reports: override, from
+// --disk-path, when set; else osPath, the one backing the OS, when it is
+// mounted from a disk; else the largest of the other mounts, preferring
+// writable ones. Without any it returns osPath, which is then read directly.
+func primaryDisk(override string, mounts []MountInfo, osPath string) string {
+	if override != "" {
+		return override
+	}
+	var best *MountInfo
+	for i := range mounts {
+		m := &mounts[i]
+		if samePath(m.Mountpoint, osPath) {
+			if !slices.Contains(virtualRootTypes, m.Fstype) {
+				return osPath
+			}
+			continue
+		}
+		if best == nil || largerMount(m, best) {
+			best = m
+		}
+	}
+	if best == nil {
+		return osPath
+	}
+	return best.Mountpoint
+}
+
+// largerMount reports whether a makes a better primary disk than b:
+// writable before read-only, then by capacity, then by the shorter
+// mountpoint so the choice is stable
+func largerMount(a, b *MountInfo) bool {
+	if a.ReadOnly != b.ReadOnly {
+		return !a.ReadOnly
+	}
+	if a.Total != b.Total {
+		return a.Total > b.Total
+	}
+	return len(a.Mountpoint) < len(b.Mountpoint)
+}
+
+// samePath compares mountpoints the way Windows reports them, "C:" for the
+// "C:\" drive root, and without regard to case there
+func samePath(mountpoint, path string) bool {
+	if mountpoint == path {
+		return true
+	}
+	trim := func(p string) string { return strings.TrimRight(p, `\`) }
+	return strings.Contains(path, `\`) && strings.EqualFold(trim(mountpoint), trim(path))
+}
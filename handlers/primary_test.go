@@ -0,0 +1,112 @@
+package handlers
+
+import "testing"
+
+func TestPrimaryDisk(t *testing.T) {
+	const gib = 1 << 30
+	overlayRoot := MountInfo{Mountpoint: "/", Fstype: "overlay", Total: 500 * gib}
+	tests := []struct {
+		name     string
+		override string
+		mounts   []MountInfo
+		osPath   string
+		want     string
+	}{
+		{
+			name:   "empty list falls back to the OS path",
+			osPath: "/",
+			want:   "/",
+		},
+		{
+			name: "OS-backing mount preferred over larger ones",
+			mounts: []MountInfo{
+				{Mountpoint: "/", Fstype: "ext4", Total: 50 * gib},
+				{Mountpoint: "/data", Fstype: "xfs", Total: 2000 * gib},
+			},
+			osPath: "/",
+			want:   "/",
+		},
+		{
+			name: "largest capacity under a virtual root",
+			mounts: []MountInfo{
+				overlayRoot,
+				{Mountpoint: "/etc/hosts", Fstype: "ext4", Total: 100 * gib},
+				{Mountpoint: "/data", Fstype: "ext4", Total: 900 * gib},
+				{Mountpoint: "/logs", Fstype: "ext4", Total: 200 * gib},
+			},
+			osPath: "/",
+			want:   "/data",
+		},
+		{
+			name: "writable preferred over a larger read-only mount",
+			mounts: []MountInfo{
+				overlayRoot,
+				{Mountpoint: "/iso", Fstype: "iso9660", ReadOnly: true, Total: 4000 * gib},
+				{Mountpoint: "/data", Fstype: "ext4", Total: 100 * gib},
+			},
+			osPath: "/",
+			want:   "/data",
+		},
+		{
+			name: "equal capacity goes to the shorter mountpoint",
+			mounts: []MountInfo{
+				overlayRoot,
+				{Mountpoint: "/etc/resolv.conf", Fstype: "ext4", Total: 100 * gib},
+				{Mountpoint: "/etc/hosts", Fstype: "ext4", Total: 100 * gib},
+			},
+			osPath: "/",
+			want:   "/etc/hosts",
+		},
+		{
+			name:   "only a virtual root falls back to the OS path",
+			mounts: []MountInfo{overlayRoot},
+			osPath: "/",
+			want:   "/",
+		},
+		{
+			name: "Windows system drive",
+			mounts: []MountInfo{
+				{Mountpoint: "C:", Fstype: "NTFS", Total: 250 * gib},
+				{Mountpoint: "D:", Fstype: "NTFS", Total: 4000 * gib},
+			},
+			osPath: `C:\`,
+			want:   `C:\`,
+		},
+		{
+			name: "Windows system drive matched without regard to case",
+			mounts: []MountInfo{
+				{Mountpoint: "D:", Fstype: "NTFS", Total: 4000 * gib},
+				{Mountpoint: "c:", Fstype: "NTFS", Total: 250 * gib},
+			},
+			osPath: `C:\`,
+			want:   `C:\`,
+		},
+		{
+			name:     "user override",
+			override: "/mnt/backup",
+			mounts: []MountInfo{
+				{Mountpoint: "/", Fstype: "ext4", Total: 50 * gib},
+				{Mountpoint: "/data", Fstype: "xfs", Total: 2000 * gib},
+			},
+			osPath: "/",
+			want:   "/mnt/backup",
+		},
+		{
+			name:     "user override under a virtual root",
+			override: "/",
+			mounts: []MountInfo{
+				overlayRoot,
+				{Mountpoint: "/data", Fstype: "ext4", Total: 900 * gib},
+			},
+			osPath: "/",
+			want:   "/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := primaryDisk(tt.override, tt.mounts, tt.osPath); got != tt.want {
+				t.Errorf("primaryDisk = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
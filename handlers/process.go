@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// ProcessStat holds resource usage for a single process. PPID is only read
+// when grouping by parent, and IO when I/O is collected and the process is
+// readable.
+type ProcessStat struct {
+	PID        int32      `json:"pid"`
+	PPID       int32      `json:"ppid,omitempty"`
+	Name       string     `json:"name"`
+	CPUPercent float64    `json:"cpuPercent"`
+	MemPercent float64    `json:"memPercent"`
+	RSS        uint64     `json:"rss"`
+	IO         *ProcessIO `json:"io,omitempty"`
+}
+
+// ProcessInfo holds the busiest processes and the total process count, and
+// with --process-grouping the busiest groups of them. Zombies counts the
+// defunct processes, nil where process states can't be read. IO is set when
+// per-process I/O was collected.
+type ProcessInfo struct {
+	Processes []ProcessStat  `json:"processes"`
+	Groups    []ProcessGroup `json:"groups,omitempty"`
+	Total     int            `json:"total"`
+	Zombies   *int           `json:"zombies,omitempty"`
+	IO        bool           `json:"io,omitempty"`
+}
+
+// ProcessTracker keeps process handles between ticks so CPU usage can be
+// measured over the interval since the previous collection, and the I/O
+// counters read then for the I/O rates
+type ProcessTracker struct {
+	mu    sync.Mutex
+	procs map[int32]*process.Process
+	io    map[int32]ioSample
+}
+
+// NewProcessTracker creates an empty process tracker
+func NewProcessTracker() *ProcessTracker {
+	return &ProcessTracker{
+		procs: make(map[int32]*process.Process),
+		io:    make(map[int32]ioSample),
+	}
+}
+
+// GetProcessInfo retrieves process information sorted by sortBy: CPU then
+// memory usage, or the I/O rate then CPU. Only the first limit processes
+// are returned (all when limit <= 0); truncation happens after sorting so
+// the busiest processes are kept. Unless grouping is GroupNone, every
+// process is also aggregated into groups before truncating. I/O is read
+// with withIO or when sorting by it.
+func (t *ProcessTracker) GetProcessInfo(limit int, grouping, sortBy string, withIO bool) (*ProcessInfo, error) {
+	pids, err := process.Pids()
+	if err != nil {
+		return nil, err
+	}
+
+	vmStat, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	withIO = withIO || sortBy == ProcessSortIO
+	blockLayer := CurrentPlatform().HasBlockIOCounters
+	now := time.Now()
+	current := make(map[int32]*process.Process, len(pids))
+	currentIO := make(map[int32]ioSample)
+	stats := make([]ProcessStat, 0, len(pids))
+	zombies, statusKnown := 0, false
+
+	for _, pid := range pids {
+		p, ok := t.procs[pid]
+		if !ok {
+			p, err = process.NewProcess(pid)
+			if err != nil {
+				// The process exited between listing and inspection
+				continue
+			}
+		}
+		current[pid] = p
+
+		stat := ProcessStat{PID: pid}
+		stat.Name, _ = p.Name()
+		stat.CPUPercent, _ = p.Percent(0)
+		if grouping == GroupParent {
+			stat.PPID, _ = p.Ppid()
+		}
+		if status, err := p.Status(); err == nil {
+			statusKnown = true
+			if slices.Contains(status, process.Zombie) {
+				zombies++
+			}
+		}
+		if memInfo, err := p.MemoryInfo(); err == nil {
+			stat.RSS = memInfo.RSS
+			if vmStat.Total > 0 {
+				stat.MemPercent = 100 * float64(memInfo.RSS) / float64(vmStat.Total)
+			}
+		}
+		if withIO {
+			prev, ok := t.io[pid]
+			var sample ioSample
+			if stat.IO, sample, ok = processIO(p, prev, ok, blockLayer, now); ok {
+				currentIO[pid] = sample
+			}
+		}
+		stats = append(stats, stat)
+	}
+	t.procs = current
+	t.io = currentIO
+
+	sort.Slice(stats, func(i, j int) bool {
+		return busier(stats[i], stats[j], sortBy)
+	})
+
+	info := &ProcessInfo{
+		Processes: stats,
+		Total:     len(stats),
+		IO:        withIO,
+	}
+	if statusKnown {
+		info.Zombies = &zombies
+	}
+	if grouping != GroupNone {
+		info.Groups = groupProcesses(stats, grouping, sortBy, limit)
+	}
+	if limit > 0 && len(info.Processes) > limit {
+		info.Processes = info.Processes[:limit]
+	}
+
+	return info, nil
+}
+
+// busier reports whether a sorts before b in the sortBy order. Processes
+// with unreadable I/O sort after every readable one by I/O.
+func busier(a, b ProcessStat, sortBy string) bool {
+	if sortBy == ProcessSortIO && a.IO.Rate() != b.IO.Rate() {
+		return a.IO.Rate() > b.IO.Rate()
+	}
+	if a.CPUPercent != b.CPUPercent {
+		return a.CPUPercent > b.CPUPercent
+	}
+	return a.MemPercent > b.MemPercent
+}
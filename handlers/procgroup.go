@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Process grouping modes selectable with --process-grouping
+const (
+	GroupNone   = "none"
+	GroupParent = "parent"
+	GroupName   = "name"
+)
+
+// ProcessGroup aggregates the processes sharing a parent or a name. For
+// parent grouping ParentPID is the parent and Name its name, or a note when
+// the parent has exited since it was listed.
+type ProcessGroup struct {
+	Key        string        `json:"key"`
+	Name       string        `json:"name"`
+	ParentPID  int32         `json:"parentPid,omitempty"`
+	Count      int           `json:"count"`
+	CPUPercent float64       `json:"cpuPercent"`
+	MemPercent float64       `json:"memPercent"`
+	RSS        uint64        `json:"rss"`
+	IO         *ProcessIO    `json:"io,omitempty"`
+	Processes  []ProcessStat `json:"processes"`
+}
+
+// groupProcesses aggregates stats, which are sorted busiest first by
+// sortBy, into groups sorted the same way. A group's IO totals its readable
+// members. Only the first limit groups, and the first
+// limit members of each, are kept (all when limit <= 0).
+func groupProcesses(stats []ProcessStat, mode, sortBy string, limit int) []ProcessGroup {
+	names := make(map[int32]string, len(stats))
+	for _, stat := range stats {
+		names[stat.PID] = stat.Name
+	}
+
+	index := make(map[string]int)
+	var groups []ProcessGroup
+	for _, stat := range stats {
+		key, group := groupFor(stat, mode, names)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, group)
+		}
+		g := &groups[i]
+		g.Count++
+		g.CPUPercent += stat.CPUPercent
+		g.MemPercent += stat.MemPercent
+		g.RSS += stat.RSS
+		addIO(&g.IO, stat.IO)
+		if limit <= 0 || len(g.Processes) < limit {
+			g.Processes = append(g.Processes, stat)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if sortBy == ProcessSortIO && groups[i].IO.Rate() != groups[j].IO.Rate() {
+			return groups[i].IO.Rate() > groups[j].IO.Rate()
+		}
+		if groups[i].CPUPercent != groups[j].CPUPercent {
+			return groups[i].CPUPercent > groups[j].CPUPercent
+		}
+		return groups[i].MemPercent > groups[j].MemPercent
+	})
+	if limit > 0 && len(groups) > limit {
+		groups = groups[:limit]
+	}
+	return groups
+}
+
+// groupFor returns the key and an empty group for the process's group
+func groupFor(stat ProcessStat, mode string, names map[int32]string) (string, ProcessGroup) {
+	if mode == GroupName {
+		key := "name:" + stat.Name
+		return key, ProcessGroup{Key: key, Name: stat.Name}
+	}
+
+	key := "parent:" + strconv.FormatInt(int64(stat.PPID), 10)
+	group := ProcessGroup{Key: key, ParentPID: stat.PPID}
+	switch name, ok := names[stat.PPID]; {
+	case stat.PPID == 0:
+		group.Name = "(no parent)"
+	case !ok:
+		// The parent exited after its children were listed, or cannot be
+		// inspected
+		group.Name = "(exited parent)"
+	default:
+		group.Name = name
+	}
+	return key, group
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// Process table orders selectable with --process-sort
+const (
+	ProcessSortCPU = "cpu"
+	ProcessSortIO  = "io"
+)
+
+// ProcessIO holds a process's cumulative storage I/O and its rates over the
+// interval since the previous collection, zero on the first
+type ProcessIO struct {
+	ReadBytes   uint64  `json:"readBytes"`
+	WriteBytes  uint64  `json:"writeBytes"`
+	ReadPerSec  float64 `json:"readPerSec"`
+	WritePerSec float64 `json:"writePerSec"`
+}
+
+// Rate is the combined read and write rate the I/O sort orders by
+func (io *ProcessIO) Rate() float64 {
+	if io == nil {
+		return -1
+	}
+	return io.ReadPerSec + io.WritePerSec
+}
+
+// ioSample is a process's counters at the time they were read
+type ioSample struct {
+	read, write uint64
+	at          time.Time
+}
+
+// ioBytes picks the storage counters: the bytes that reached the block
+// layer where the platform has them, otherwise everything read and written
+func ioBytes(counters *process.IOCountersStat, blockLayer bool) (read, write uint64) {
+	if blockLayer {
+		return counters.DiskReadBytes, counters.DiskWriteBytes
+	}
+	return counters.ReadBytes, counters.WriteBytes
+}
+
+// processIO reads p's I/O counters and the rates since prev. Other users'
+// processes are unreadable without privileges on Linux and macOS, so they
+// go without I/O rather than failing the table.
+func processIO(p *process.Process, prev ioSample, havePrev, blockLayer bool, now time.Time) (*ProcessIO, ioSample, bool) {
+	counters, err := p.IOCounters()
+	if err != nil {
+		return nil, ioSample{}, false
+	}
+	read, write := ioBytes(counters, blockLayer)
+	io := &ProcessIO{ReadBytes: read, WriteBytes: write}
+	if elapsed := now.Sub(prev.at).Seconds(); havePrev && elapsed > 0 {
+		// A PID reused since the previous read has counters that went
+		// backwards, and reads as idle
+		io.ReadPerSec = counterRate(prev.read, read, elapsed)
+		io.WritePerSec = counterRate(prev.write, write, elapsed)
+	}
+	return io, ioSample{read: read, write: write, at: now}, true
+}
+
+// addIO folds a member's I/O into a group's total
+func addIO(total **ProcessIO, io *ProcessIO) {
+	if io == nil {
+		return
+	}
+	if *total == nil {
+		*total = &ProcessIO{}
+	}
+	t := *total
+	t.ReadBytes += io.ReadBytes
+	t.WriteBytes += io.WriteBytes
+	t.ReadPerSec += io.ReadPerSec
+	t.WritePerSec += io.WritePerSec
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// smartctlTimeout bounds each smartctl run; sleeping disks can take a few
+// seconds to answer
+const smartctlTimeout = 15 * time.Second
+
+// DiskHealth holds the SMART status of one physical disk. The attribute
+// fields are nil when the drive doesn't report them; Error is set when the
+// drive could not be queried, in which case Passed is meaningless.
+type DiskHealth struct {
+	Device             string `json:"device"`
+	Model              string `json:"model,omitempty"`
+	Serial             string `json:"serial,omitempty"`
+	Passed             bool   `json:"passed"`
+	Temperature        *int64 `json:"temperature,omitempty"`
+	PowerOnHours       *int64 `json:"powerOnHours,omitempty"`
+	ReallocatedSectors *int64 `json:"reallocatedSectors,omitempty"`
+	PendingSectors     *int64 `json:"pendingSectors,omitempty"`
+	MediaErrors        *int64 `json:"mediaErrors,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// SMART attribute IDs reported for ATA drives
+const (
+	smartReallocatedSectors = 5
+	smartPendingSectors     = 197
+)
+
+// smartctlOutput is the subset of `smartctl --json` output that is read
+type smartctlOutput struct {
+	Smartctl struct {
+		Messages []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	Devices []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"devices"`
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SmartStatus  *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current int64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	ATASmartAttributes *struct {
+		Table []struct {
+			ID  int `json:"id"`
+			Raw struct {
+				Value int64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeHealth *struct {
+		MediaErrors int64 `json:"media_errors"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+// SmartctlAvailable reports whether smartctl is on the PATH
+func SmartctlAvailable() bool {
+	_, err := exec.LookPath("smartctl")
+	return err == nil
+}
+
+// GetDiskHealth queries every disk smartctl can find. Disks that fail to
+// answer are reported with their error rather than failing the whole
+// collection; when every disk is refused for lack of privileges the error
+// wraps os.ErrPermission.
+func GetDiskHealth() ([]DiskHealth, error) {
+	scan, err := runSmartctl("--scan")
+	if err != nil {
+		return nil, err
+	}
+
+	disks := make([]DiskHealth, 0, len(scan.Devices))
+	denied := 0
+	for _, device := range scan.Devices {
+		health := DiskHealth{Device: device.Name}
+		args := []string{"--info", "--health", "--attributes"}
+		if device.Type != "" {
+			args = append(args, "--device", device.Type)
+		}
+		out, err := runSmartctl(append(args, device.Name)...)
+		if err != nil {
+			health.Error = err.Error()
+			if errors.Is(err, os.ErrPermission) {
+				denied++
+			}
+		} else {
+			health.fill(out)
+		}
+		disks = append(disks, health)
+	}
+
+	if denied > 0 && denied == len(disks) {
+		return nil, fmt.Errorf("querying disks with smartctl: %w", os.ErrPermission)
+	}
+	return disks, nil
+}
+
+// fill copies the SMART status and key attributes from smartctl output
+func (h *DiskHealth) fill(out *smartctlOutput) {
+	h.Model = out.ModelName
+	h.Serial = out.SerialNumber
+	if out.SmartStatus != nil {
+		h.Passed = out.SmartStatus.Passed
+	} else {
+		h.Error = "drive does not report an overall SMART status"
+	}
+	if out.Temperature != nil {
+		h.Temperature = &out.Temperature.Current
+	}
+	if out.PowerOnTime != nil {
+		h.PowerOnHours = &out.PowerOnTime.Hours
+	}
+	if out.ATASmartAttributes != nil {
+		for _, attr := range out.ATASmartAttributes.Table {
+			raw := attr.Raw.Value
+			switch attr.ID {
+			case smartReallocatedSectors:
+				h.ReallocatedSectors = &raw
+			case smartPendingSectors:
+				h.PendingSectors = &raw
+			}
+		}
+	}
+	if out.NVMeHealth != nil {
+		h.MediaErrors = &out.NVMeHealth.MediaErrors
+	}
+}
+
+// runSmartctl runs smartctl with JSON output. smartctl's exit status is a
+// bit mask that is also set for failing drives, so only the "command line
+// did not parse" and "device open failed" bits (0 and 1) count as errors,
+// reported with smartctl's own message.
+func runSmartctl(args ...string) (*smartctlOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), smartctlTimeout)
+	defer cancel()
+
+	stdout, runErr := exec.CommandContext(ctx, "smartctl", append([]string{"--json"}, args...)...).Output()
+
+	var out smartctlOutput
+	if err := json.Unmarshal(stdout, &out); err != nil {
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, fmt.Errorf("decoding smartctl output: %w", err)
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && (!errors.As(runErr, &exitErr) || exitErr.ExitCode()&0b11 != 0) {
+		return nil, smartctlError(&out, runErr)
+	}
+	return &out, nil
+}
+
+// smartctlError reports smartctl's own error messages, wrapping
+// os.ErrPermission when the device could not be opened for lack of
+// privileges
+func smartctlError(out *smartctlOutput, runErr error) error {
+	var messages []string
+	for _, m := range out.Smartctl.Messages {
+		if m.Severity == "error" {
+			messages = append(messages, m.String)
+		}
+	}
+	if len(messages) == 0 {
+		return runErr
+	}
+
+	message := strings.Join(messages, "; ")
+	if strings.Contains(message, "Permission denied") || strings.Contains(message, "Operation not permitted") {
+		return smartctlDenied(message)
+	}
+	return errors.New(message)
+}
+
+// smartctlDenied is smartctl's own permission message, matching
+// os.ErrPermission without repeating it
+type smartctlDenied string
+
+func (e smartctlDenied) Error() string { return string(e) }
+
+func (e smartctlDenied) Unwrap() error { return os.ErrPermission }
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// HOST-RESOURCES-MIB and SNMPv2-MIB objects read by SNMPSource
+const (
+	oidSysDescr          = ".1.3.6.1.2.1.1.1.0"
+	oidSysName           = ".1.3.6.1.2.1.1.5.0"
+	oidHrSystemProcesses = ".1.3.6.1.2.1.25.1.6.0"
+	oidHrStorageEntry    = ".1.3.6.1.2.1.25.2.3.1"
+	oidHrDeviceDescr     = ".1.3.6.1.2.1.25.3.2.1.3"
+	oidHrProcessorLoad   = ".1.3.6.1.2.1.25.3.3.1.2"
+
+	oidHrStorageRAM       = ".1.3.6.1.2.1.25.2.1.2"
+	oidHrStorageFixedDisk = ".1.3.6.1.2.1.25.2.1.4"
+)
+
+// hrStorageEntry column numbers
+const (
+	hrStorageType  = 2
+	hrStorageDescr = 3
+	hrStorageUnits = 4
+	hrStorageSize  = 5
+	hrStorageUsed  = 6
+)
+
+const defaultSNMPPort = 161
+
+// SNMPSource reads system, CPU and disk metrics from a remote host over
+// SNMP v2c using HOST-RESOURCES-MIB
+type SNMPSource struct {
+	// gosnmp connections are not safe for concurrent use and the system,
+	// CPU and disk collectors poll independently
+	mu   sync.Mutex
+	snmp *gosnmp.GoSNMP
+}
+
+// NewSNMPSource connects to target ("host" or "host:port"). SNMP runs over
+// UDP, so an unreachable host only shows up as timeouts when polled.
+func NewSNMPSource(target, community string, timeout time.Duration) (*SNMPSource, error) {
+	host, port := target, uint16(defaultSNMPPort)
+	if h, p, err := net.SplitHostPort(target); err == nil {
+		n, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SNMP port %q", p)
+		}
+		host, port = h, uint16(n)
+	}
+
+	snmp := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      port,
+		Community: community,
+		Version:   gosnmp.Version2c,
+		Timeout:   timeout,
+		Retries:   1,
+	}
+	if err := snmp.Connect(); err != nil {
+		return nil, err
+	}
+
+	return &SNMPSource{snmp: snmp}, nil
+}
+
+// GetSystemInfo reads the device's name, description, process count and
+// physical memory. Objects the agent does not expose are listed in
+// Unavailable rather than failing the poll.
+func (s *SNMPSource) GetSystemInfo() (*SystemInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	packet, err := s.snmp.Get([]string{oidSysName, oidSysDescr, oidHrSystemProcesses})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SystemInfo{}
+	for _, pdu := range packet.Variables {
+		if !snmpHasValue(pdu) {
+			switch pdu.Name {
+			case oidSysName:
+				info.Unavailable = append(info.Unavailable, MetricHostname)
+			case oidSysDescr:
+				info.Unavailable = append(info.Unavailable, MetricPlatform)
+			case oidHrSystemProcesses:
+				info.Unavailable = append(info.Unavailable, MetricProcesses)
+			}
+			continue
+		}
+
+		switch pdu.Name {
+		case oidSysName:
+			info.Hostname = snmpString(pdu)
+		case oidSysDescr:
+			info.Platform = snmpString(pdu)
+			info.OS, _, _ = strings.Cut(info.Platform, " ")
+		case oidHrSystemProcesses:
+			info.Procs = gosnmp.ToBigInt(pdu.Value).Uint64()
+		}
+	}
+
+	storage, err := s.storageEntries()
+	if err != nil {
+		return nil, err
+	}
+	ram, ok := findStorage(storage, oidHrStorageRAM)
+	if ok && ram.size > 0 {
+		total := ram.size * ram.units
+		used := ram.used * ram.units
+		info.TotalMem = total / mebibyteDiv
+		info.FreeMem = (total - min(used, total)) / mebibyteDiv
+		info.UsedPercent = sanitizePercent(MetricMemory, 100*float64(used)/float64(total))
+	} else {
+		info.Unavailable = append(info.Unavailable, MetricMemory)
+	}
+
+	return info, nil
+}
+
+// GetCPUInfo reads per-processor load. hrProcessorLoad is a one-minute
+// average maintained by the agent, not an instantaneous reading.
+func (s *SNMPSource) GetCPUInfo() (*CPUInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loads, err := s.snmp.BulkWalkAll(oidHrProcessorLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &CPUInfo{Percentages: []float64{}}
+	for _, pdu := range loads {
+		info.Percentages = append(info.Percentages, float64(gosnmp.ToBigInt(pdu.Value).Int64()))
+	}
+
+	// The processor table is indexed by hrDeviceIndex, so the first entry
+	// names the CPU model
+	if len(loads) > 0 {
+		index := strings.TrimPrefix(loads[0].Name, oidHrProcessorLoad)
+		if packet, err := s.snmp.Get([]string{oidHrDeviceDescr + index}); err == nil && len(packet.Variables) > 0 {
+			if pdu := packet.Variables[0]; snmpHasValue(pdu) {
+				info.ModelName = snmpString(pdu)
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// GetDiskInfo reports every fixed disk in hrStorageTable. The primary disk
+// is the root filesystem when present, otherwise the first fixed disk.
+func (s *SNMPSource) GetDiskInfo() (*DiskInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	storage, err := s.storageEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &DiskInfo{Mounts: []MountInfo{}}
+	for _, entry := range storage {
+		if entry.storageType != oidHrStorageFixedDisk || entry.size == 0 {
+			continue
+		}
+
+		total := entry.size * entry.units
+		used := min(entry.used*entry.units, total)
+		info.Mounts = append(info.Mounts, MountInfo{
+			Mountpoint:  entry.descr,
+			Total:       total,
+			Used:        used,
+			Free:        total - used,
+			UsedPercent: sanitizePercent("disk", 100*float64(used)/float64(total)),
+		})
+	}
+	if len(info.Mounts) == 0 {
+		return nil, fmt.Errorf("SNMP agent reports no fixed disks")
+	}
+
+	sort.Slice(info.Mounts, func(i, j int) bool {
+		return info.Mounts[i].Mountpoint < info.Mounts[j].Mountpoint
+	})
+
+	primary := info.Mounts[0]
+	for _, m := range info.Mounts {
+		if m.Mountpoint == "/" {
+			primary = m
+			break
+		}
+	}
+	info.Total = primary.Total
+	info.Used = primary.Used
+	info.Free = primary.Free
+	info.UsedPercent = primary.UsedPercent
+
+	return info, nil
+}
+
+// snmpStorage is one row of hrStorageTable
+type snmpStorage struct {
+	storageType string
+	descr       string
+	units       uint64
+	size        uint64
+	used        uint64
+}
+
+// storageEntries walks hrStorageTable; callers must hold mu
+func (s *SNMPSource) storageEntries() ([]snmpStorage, error) {
+	pdus, err := s.snmp.BulkWalkAll(oidHrStorageEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]*snmpStorage)
+	var order []string
+	for _, pdu := range pdus {
+		// Names look like <entry>.<column>.<index>
+		column, index, found := strings.Cut(strings.TrimPrefix(pdu.Name, oidHrStorageEntry+"."), ".")
+		if !found {
+			continue
+		}
+		row, ok := rows[index]
+		if !ok {
+			row = &snmpStorage{}
+			rows[index] = row
+			order = append(order, index)
+		}
+
+		switch column {
+		case strconv.Itoa(hrStorageType):
+			row.storageType = snmpString(pdu)
+		case strconv.Itoa(hrStorageDescr):
+			row.descr = snmpString(pdu)
+		case strconv.Itoa(hrStorageUnits):
+			row.units = gosnmp.ToBigInt(pdu.Value).Uint64()
+		case strconv.Itoa(hrStorageSize):
+			row.size = gosnmp.ToBigInt(pdu.Value).Uint64()
+		case strconv.Itoa(hrStorageUsed):
+			row.used = gosnmp.ToBigInt(pdu.Value).Uint64()
+		}
+	}
+
+	entries := make([]snmpStorage, 0, len(order))
+	for _, index := range order {
+		entries = append(entries, *rows[index])
+	}
+	return entries, nil
+}
+
+func findStorage(entries []snmpStorage, storageType string) (snmpStorage, bool) {
+	for _, entry := range entries {
+		if entry.storageType == storageType {
+			return entry, true
+		}
+	}
+	return snmpStorage{}, false
+}
+
+// snmpHasValue reports whether the agent returned the object at all
+func snmpHasValue(pdu gosnmp.SnmpPDU) bool {
+	switch pdu.Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+		return false
+	}
+	return true
+}
+
+func snmpString(pdu gosnmp.SnmpPDU) string {
+	switch v := pdu.Value.(type) {
+	case []byte:
+		return strings.TrimSpace(string(v))
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
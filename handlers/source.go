@@ -0,0 +1,51 @@
+package handlers
+
+// Source provides the host-level metrics shown in the system, CPU and disk
+// panels. The local host is the default; SNMPSource polls a remote device
+// instead.
+type Source interface {
+	GetSystemInfo() (*SystemInfo, error)
+	GetCPUInfo() (*CPUInfo, error)
+	GetDiskInfo() (*DiskInfo, error)
+}
+
+type localSource struct {
+	steal    *StealTracker
+	diskIO   *DiskIOTracker
+	sampler  *CPUSampler
+	diskPath string
+}
+
+// LocalSource returns the Source reading the machine the monitor runs on.
+// CPU usage comes from sampler when it is set, and is otherwise measured
+// since the previous reading. The disk panel reports diskPath, or the
+// primary disk when it is empty.
+func LocalSource(sampler *CPUSampler, diskPath string) Source {
+	return localSource{steal: NewStealTracker(), diskIO: NewDiskIOTracker(), sampler: sampler, diskPath: diskPath}
+}
+
+func (localSource) GetSystemInfo() (*SystemInfo, error) { return GetSystemInfo() }
+
+// GetDiskInfo adds the disk I/O since the previous reading
+func (l localSource) GetDiskInfo() (*DiskInfo, error) {
+	info, err := GetDiskInfo(l.diskPath)
+	if err != nil {
+		return nil, err
+	}
+	info.IO = l.diskIO.Read()
+	return info, nil
+}
+
+// GetCPUInfo adds the steal percentage since the previous reading
+func (l localSource) GetCPUInfo() (*CPUInfo, error) {
+	percentages := getCPUPercentages
+	if l.sampler != nil {
+		percentages = l.sampler.Latest
+	}
+	info, err := getCPUInfo(percentages)
+	if err != nil {
+		return nil, err
+	}
+	info.Steal = l.steal.Percent()
+	return info, nil
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"sync"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+// StealTracker computes the share of CPU time the hypervisor ran other
+// guests instead of this one, from successive aggregate CPU time readings
+type StealTracker struct {
+	mu   sync.Mutex
+	last *cpu.TimesStat
+}
+
+// NewStealTracker creates an empty steal tracker
+func NewStealTracker() *StealTracker {
+	return &StealTracker{}
+}
+
+// Percent returns the steal percentage since the previous call. It is nil on
+// the first call, on platforms that don't report steal, and when the CPU
+// times cannot be read, so the CPU panel leaves the row out.
+func (t *StealTracker) Percent() *float64 {
+	if !CurrentPlatform().HasStealCounter {
+		return nil
+	}
+	times, err := cpu.Times(false)
+	if err != nil || len(times) == 0 {
+		return nil
+	}
+	cur := times[0]
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev := t.last
+	t.last = &cur
+	if prev == nil {
+		return nil
+	}
+
+	elapsed := busyAndIdle(cur) - busyAndIdle(*prev)
+	if elapsed <= 0 {
+		return nil
+	}
+	steal := sanitizePercent("steal", 100*(cur.Steal-prev.Steal)/elapsed)
+	return &steal
+}
+
+// busyAndIdle sums the CPU time counters. Guest time is already counted in
+// user and nice on Linux, so unlike TimesStat.Total it is left out.
+func busyAndIdle(t cpu.TimesStat) float64 {
+	return t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
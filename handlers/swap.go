@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// swapPageBytes is the page size gopsutil assumes when it turns the kernel's
+// swapped page counts into the byte counters Sin and Sout
+const swapPageBytes = 4 * 1024
+
+// SwapInfo holds swap usage in bytes and paging rates in pages per second.
+// The rates are nil on platforms without swap paging counters.
+type SwapInfo struct {
+	Total          uint64   `json:"total"`
+	Used           uint64   `json:"used"`
+	Free           uint64   `json:"free"`
+	UsedPercent    float64  `json:"usedPercent"`
+	PagesInPerSec  *float64 `json:"pagesInPerSec,omitempty"`
+	PagesOutPerSec *float64 `json:"pagesOutPerSec,omitempty"`
+}
+
+// SwapTracker computes paging rates from successive swap counter readings
+type SwapTracker struct {
+	mu       sync.Mutex
+	last     *mem.SwapMemoryStat
+	lastTime time.Time
+}
+
+// NewSwapTracker creates an empty swap tracker
+func NewSwapTracker() *SwapTracker {
+	return &SwapTracker{}
+}
+
+// GetSwapInfo retrieves swap usage and the paging rates since the previous
+// call. The first call reports zero rates.
+func (t *SwapTracker) GetSwapInfo() (*SwapInfo, error) {
+	stat, err := mem.SwapMemory()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	info := &SwapInfo{
+		Total:       stat.Total,
+		Used:        stat.Used,
+		Free:        stat.Free,
+		UsedPercent: sanitizePercent("swap", stat.UsedPercent),
+	}
+	if !CurrentPlatform().HasPagingCounters {
+		return info, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var pagesIn, pagesOut float64
+	if elapsed := now.Sub(t.lastTime).Seconds(); t.last != nil && elapsed > 0 {
+		pagesIn = counterRate(t.last.Sin, stat.Sin, elapsed) / swapPageBytes
+		pagesOut = counterRate(t.last.Sout, stat.Sout, elapsed) / swapPageBytes
+	}
+	info.PagesInPerSec = &pagesIn
+	info.PagesOutPerSec = &pagesOut
+
+	t.last = stat
+	t.lastTime = now
+
+	return info, nil
+}
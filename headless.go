@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"system-monitor/format"
+	"system-monitor/metrics"
+
+	"github.com/mattn/go-isatty"
+)
+
+// ANSI escape sequences used by the headless output
+const (
+	ansiReset  = "\033[0m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiRed    = "\033[31m"
+	ansiDim    = "\033[2m"
+)
+
+// useColor reports whether headless output should be colorized: only when
+// stdout is a terminal, and never with --no-color or $NO_COLOR set
+func useColor(cfg *Config) bool {
+	if cfg.NoColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// runHeadless prints one summary line per tick to stdout instead of serving
+// the dashboard
+func (s *Server) runHeadless() {
+	color := useColor(s.getConfig())
+	s.collectors.start()
+
+	interval := s.getConfig().Interval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// Pick up an --interval changed by a reload
+		if next := s.getConfig().Interval; next != interval {
+			interval = next
+			ticker.Reset(interval)
+		}
+
+		snapshot, err := s.collectSnapshot()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error collecting metrics: %v\n", err)
+			continue
+		}
+		fmt.Println(s.headlessLine(snapshot, color))
+	}
+}
+
+// headlessLine renders a snapshot as a single line, coloring each metric by
+// the same thresholds that drive the health summary
+func (s *Server) headlessLine(snapshot *metrics.Snapshot, color bool) string {
+	cfg := s.getConfig()
+	thresholds := cfg.Health
+	health := metrics.EvaluateHealth(snapshot.System, snapshot.CPU, snapshot.Disk, thresholds)
+	sample := snapshot.Sample()
+
+	paint := func(status metrics.HealthStatus, text string) string {
+		if !color {
+			return text
+		}
+		return statusColor(status) + text + ansiReset
+	}
+	dim := func(text string) string {
+		if !color {
+			return text
+		}
+		return ansiDim + text + ansiReset
+	}
+	percent := func(label string, value float64, t metrics.Threshold) string {
+		return dim(label) + " " + paint(t.Status(value), fmt.Sprintf("%5.1f%%", value))
+	}
+
+	fields := []string{
+		dim(snapshot.Time.In(cfg.Location).Format(timestampLayout)),
+		paint(health.Status, fmt.Sprintf("%-8s", strings.ToUpper(health.Status.String()))),
+	}
+	if snapshot.CPU != nil {
+		fields = append(fields, percent("cpu", sample.CPUPercent, thresholds.CPU))
+	}
+	if snapshot.System != nil {
+		fields = append(fields, percent("mem", sample.MemUsedPercent, thresholds.Memory))
+	}
+	if snapshot.Disk != nil {
+		fields = append(fields, percent("disk", sample.DiskUsedPercent, thresholds.Disk))
+	}
+	if snapshot.Network != nil {
+		fields = append(fields, dim("net")+" ↑"+format.Rate(sample.NetSentPerSec)+" ↓"+format.Rate(sample.NetRecvPerSec))
+	}
+	if load := snapshot.Load; load != nil {
+		fields = append(fields, dim("load")+fmt.Sprintf(" %.2f %.2f %.2f", load.Load1, load.Load5, load.Load15))
+	}
+
+	return strings.Join(fields, "  ")
+}
+
+func statusColor(status metrics.HealthStatus) string {
+	switch status {
+	case metrics.HealthCritical:
+		return ansiRed
+	case metrics.HealthWarning:
+		return ansiYellow
+	default:
+		return ansiGreen
+	}
+}
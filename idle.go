@@ -0,0 +1,141 @@
+package main
+
+import (
+	"sync"
+
+	"system-monitor/console"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// idleGate pauses collection while no one is watching, under --idle-pause.
+// The publisher closes it when a tick finds no subscribers and the first
+// connect opens it again, waking the publisher for an immediate snapshot.
+// A nil gate never pauses.
+type idleGate struct {
+	mu     sync.Mutex
+	paused bool
+	// open is closed while collection runs and replaced on each pause
+	open chan struct{}
+	// wake tells the publisher collection has resumed
+	wake chan struct{}
+}
+
+// newIdleGate returns the gate for --idle-pause, or nil when collection
+// has to keep running: without the flag, or while an export, the snapshot
+// file or the history database consumes every snapshot
+func (s *Server) newIdleGate() *idleGate {
+	if !s.getConfig().IdlePause {
+		return nil
+	}
+	// The live streams are always the first sink
+	if s.store != nil || len(s.sinks) > 1 {
+		console.Println(console.Warning, "--idle-pause has no effect while exports or the history database record every snapshot")
+		return nil
+	}
+	g := &idleGate{open: make(chan struct{}), wake: make(chan struct{}, 1)}
+	close(g.open)
+	return g
+}
+
+// pause closes the gate, reporting whether it was open
+func (g *idleGate) pause() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused {
+		return false
+	}
+	g.paused = true
+	g.open = make(chan struct{})
+	return true
+}
+
+// resume opens the gate and wakes the publisher, reporting whether it was
+// paused
+func (g *idleGate) resume() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.paused {
+		return false
+	}
+	g.paused = false
+	close(g.open)
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+func (g *idleGate) isPaused() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// wait blocks while the gate is paused, reporting whether it had to
+func (g *idleGate) wait() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	open, paused := g.open, g.paused
+	g.mu.Unlock()
+	if paused {
+		<-open
+	}
+	return paused
+}
+
+// woken delivers a value each time collection resumes; nil, and so never
+// ready, without a gate
+func (g *idleGate) woken() <-chan struct{} {
+	if g == nil {
+		return nil
+	}
+	return g.wake
+}
+
+// pauseIfIdle pauses collection when no one is subscribed and reports
+// whether it is paused. Collectors warm up first, so there is a snapshot
+// to serve on waking. It holds subscribersMu so a client connecting
+// meanwhile can't be left waiting on a paused publisher.
+func (s *Server) pauseIfIdle() bool {
+	if s.idle == nil || !s.ready.Load() {
+		return false
+	}
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	if len(s.subscribers) > 0 {
+		return false
+	}
+	if s.idle.pause() {
+		console.Println(console.Idle, "No subscribers, pausing collection until one connects")
+	}
+	return true
+}
+
+// wakeForPull collects a fresh snapshot for an HTTP request while
+// collection is paused, so polled endpoints never serve one from before
+// the pause
+func (s *Server) wakeForPull(c *fiber.Ctx) {
+	if !s.idle.isPaused() {
+		return
+	}
+	reply := make(chan refreshResult, 1)
+	select {
+	case s.refreshRequests <- reply:
+		<-reply
+	case <-c.Context().Done():
+	case <-s.ctx.Done():
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net"
+	"os"
+)
+
+// listen serves on TCP port 6080, or only on the Unix domain socket at path
+// when --unix-socket is set. The socket file is removed once the server
+// shuts down.
+func (s *Server) listen(path string) error {
+	if path == "" {
+		return s.app.Listen(":6080")
+	}
+
+	// A socket left behind by a run that was killed would fail the bind
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+	return s.app.Listener(ln)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+
+	"system-monitor/console"
+)
+
+// logLevel is the --log-level every log handler filters on; a reload
+// swaps it
+var logLevel slog.LevelVar
+
+// newLogHandler returns the handler writing the monitor's log to w in the
+// --log-format
+func newLogHandler(format string, w io.Writer) slog.Handler {
+	if format == logFormatJSON {
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: &logLevel, ReplaceAttr: dropIcon})
+	}
+	return console.NewHandler(w, &logLevel)
+}
+
+// dropIcon leaves status line icons out of JSON records, where they are
+// only decoration
+func dropIcon(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == console.IconKey {
+		return slog.Attr{}
+	}
+	return a
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// logClientBuffer bounds the lines queued for each /api/logs client;
+	// a client that falls further behind misses lines instead of growing
+	// memory
+	logClientBuffer = 256
+	// logBacklog is how many recent lines a new client is sent first
+	logBacklog = 100
+)
+
+// logHub fans the monitor's log out to the /api/logs clients, one line per
+// record
+type logHub struct {
+	mu      sync.Mutex
+	recent  []string
+	clients map[chan string]struct{}
+}
+
+func newLogHub() *logHub {
+	return &logHub{clients: make(map[chan string]struct{})}
+}
+
+// Write takes one formatted record from the hub's line handler
+func (h *logHub) Write(p []byte) (int, error) {
+	h.broadcast(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+func (h *logHub) broadcast(line string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.recent = append(h.recent, line)
+	if len(h.recent) > logBacklog {
+		h.recent = h.recent[len(h.recent)-logBacklog:]
+	}
+	for client := range h.clients {
+		select {
+		case client <- line:
+		default:
+		}
+	}
+}
+
+// logHandler is the slog.Handler behind the shared logger while /api/logs
+// is enabled. It passes each record on to next, the handler printing the
+// log, and to lines, which formats it into the hub.
+type logHandler struct {
+	next  slog.Handler
+	lines slog.Handler
+}
+
+// handler wraps next so that records also reach the hub's clients, in the
+// given --log-format
+func (h *logHub) handler(next slog.Handler, format string) slog.Handler {
+	return logHandler{next: next, lines: newLogHandler(format, h)}
+}
+
+func (h logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level) || h.lines.Enabled(ctx, level)
+}
+
+func (h logHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	if h.next.Enabled(ctx, r.Level) {
+		err = h.next.Handle(ctx, r.Clone())
+	}
+	if h.lines.Enabled(ctx, r.Level) {
+		err = errors.Join(err, h.lines.Handle(ctx, r))
+	}
+	return err
+}
+
+func (h logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return logHandler{next: h.next.WithAttrs(attrs), lines: h.lines.WithAttrs(attrs)}
+}
+
+func (h logHandler) WithGroup(name string) slog.Handler {
+	return logHandler{next: h.next.WithGroup(name), lines: h.lines.WithGroup(name)}
+}
+
+// subscribe registers a client, returning its channel and the recent lines
+func (h *logHub) subscribe() (chan string, []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	client := make(chan string, logClientBuffer)
+	h.clients[client] = struct{}{}
+	return client, append([]string(nil), h.recent...)
+}
+
+func (h *logHub) unsubscribe(client chan string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, client)
+}
+
+// logsHandler streams the monitor's log lines as Server-Sent Events,
+// starting with the most recent ones
+func (s *Server) logsHandler(c *fiber.Ctx) error {
+	if s.logs == nil {
+		return newAPIError(fiber.StatusNotFound, "log streaming disabled", "")
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	client, backlog := s.logs.subscribe()
+	streamCtx, cancel := context.WithCancel(s.ctx)
+	ctx := c.Context()
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		defer s.logs.unsubscribe(client)
+
+		for _, line := range backlog {
+			writeEvent(w, []byte(line))
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		keepalive := time.NewTicker(sseKeepaliveInterval)
+		defer keepalive.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-streamCtx.Done():
+				return
+			case line := <-client:
+				writeEvent(w, []byte(line))
+				if err := w.Flush(); err != nil {
+					return
+				}
+			case <-keepalive.C:
+				if err := writeKeepalive(w); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"system-monitor/console"
+)
+
+func TestLogHub(t *testing.T) {
+	var out bytes.Buffer
+	hub := newLogHub()
+	logger := slog.New(hub.handler(console.NewHandler(&out, nil), logFormatText))
+
+	logger.Info("before the client")
+	client, backlog := hub.subscribe()
+	defer hub.unsubscribe(client)
+	if len(backlog) != 1 || backlog[0] != "before the client" {
+		t.Errorf("backlog = %q, want the earlier line", backlog)
+	}
+
+	logger.With("total", 2).Info("Added subscriber")
+	if line := <-client; line != "Added subscriber total=2" {
+		t.Errorf("client got %q", line)
+	}
+	if want := "before the client\nAdded subscriber total=2\n"; out.String() != want {
+		t.Errorf("printed %q, want %q", out.String(), want)
+	}
+
+	// A client that stops reading misses lines instead of blocking the log
+	for i := range logClientBuffer + 10 {
+		logger.Info(fmt.Sprintf("line %d", i))
+	}
+	if len(client) != logClientBuffer {
+		t.Errorf("client queued %d lines, want %d", len(client), logClientBuffer)
+	}
+	if _, backlog := hub.subscribe(); len(backlog) != logBacklog {
+		t.Errorf("backlog holds %d lines, want %d", len(backlog), logBacklog)
+	}
+}
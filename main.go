@@ -3,72 +3,338 @@ package main
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"system-monitor/assets"
+	"system-monitor/console"
+	"system-monitor/format"
 	"system-monitor/handlers"
+	"system-monitor/metrics"
+	"system-monitor/telemetry"
 	"system-monitor/templates"
-	"sync"
 	"time"
 
+	fastws "github.com/fasthttp/websocket"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/favicon"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/gofiber/websocket/v2"
+	"google.golang.org/grpc"
 )
 
 type Server struct {
 	subscriberMessageBuffer int
 	subscribersMu           sync.Mutex
 	subscribers             map[*Subscriber]struct{}
+	lastFrames              map[string][]byte
+	lastPrepared            map[string]*fastws.PreparedMessage
+	lastLevels              metrics.HealthLevels
 	app                     *fiber.App
+	config                  atomic.Pointer[Config]
+	platform                handlers.Platform
+	source                  handlers.Source
+	hasNUMA                 bool
+	hasPressure             bool
+	hasFD                   bool
+	hasClock                bool
+	netTracker              *handlers.NetRateTracker
+	procTracker             *handlers.ProcessTracker
+	swapTracker             *handlers.SwapTracker
+	containerTracker        *handlers.ContainerTracker
+	hasSmartctl             bool
+	collectors              *collectors
+	replay                  *replay
+	telemetry               *telemetry.Telemetry
+	history                 *metrics.History
+	mountHistory            *metrics.MountHistory
+	annotations             *metrics.Annotations
+	diskTrends              *metrics.MountTrends
+	cpuAverage              *metrics.EMA
+	latestMu                sync.RWMutex
+	latest                  *metrics.Snapshot
+	latestSeq               uint64
+	deltas                  *deltaLog
+	store                   *metrics.Store
+	sinks                   []publishSink
+	fleet                   *fleet
+	reverseDNS              *reverseDNS
+	logs                    *logHub
+	quiet                   quietWindow
+	ready                   atomic.Bool
+	refreshRequests         chan chan refreshResult
+	idle                    *idleGate
+	grpcServer              *grpc.Server
+	ctx                     context.Context
+	cancel                  context.CancelFunc
 }
 
+// Subscriber receives published frames; conn is nil for SSE and gRPC clients
 type Subscriber struct {
-	msgs chan []byte
+	msgs chan streamMessage
 	conn *websocket.Conn
+	// ctx is the connection's context, derived from the server's and
+	// cancelled when the client disconnects
+	ctx context.Context
+	// paused subscribers are skipped by publishMsg; guarded by subscribersMu
+	paused bool
+	// done is closed when the subscriber is removed, ending its stream.
+	// msgs itself is never closed, so a send racing the removal, as during
+	// shutdown, is dropped rather than panicking.
+	done chan struct{}
+	// alertOnly subscribers only receive frames that change a metric's
+	// health status; levels are the statuses of the last frame they got,
+	// guarded by levelsMu
+	alertOnly bool
+	levelsMu  sync.Mutex
+	levels    *metrics.HealthLevels
+	// format is the frame format the subscriber receives, and protocol the
+	// websocket subprotocol it negotiated, if any
+	format   string
+	protocol string
+	// compressed websocket subscribers negotiated permessage-deflate and
+	// are sent the shared compressed frames
+	compressed bool
+	// fetching is set while a {"get":...} request is being answered, so a
+	// client cannot queue up collections by sending many
+	fetching atomic.Bool
+
+	transport   string
+	remoteAddr  string
+	connectedAt time.Time
+}
+
+// NewServer builds a server that writes structured records, such as the JSON
+// access log, to log
+func NewServer(cfg *Config, store *metrics.Store, log *slog.Logger) *Server {
+	return newServer(cfg, store, log, nil)
 }
 
-func NewServer() *Server {
+// newServer builds a server whose system, CPU and disk panels read source.
+// A nil source reads the local host, or the --snmp-target; tests pass a fake
+// one.
+func newServer(cfg *Config, store *metrics.Store, log *slog.Logger, source handlers.Source) *Server {
 	app := fiber.New(fiber.Config{
 		DisableStartupMessage: false,
+		ErrorHandler:          errorHandler,
 	})
 
-	// Add logger middleware
-	app.Use(logger.New(logger.Config{
-		Format: "[${ip}]:${port} ${status} - ${method} ${path}\n",
+	// Serve the embedded favicon ahead of the logger so browsers' automatic
+	// requests don't clutter the access log
+	app.Use(favicon.New(favicon.Config{
+		File:       "static/favicon.ico",
+		FileSystem: http.FS(assets.Static),
 	}))
 
+	if accessLog := accessLogger(cfg, log); accessLog != nil {
+		app.Use(accessLog)
+	}
+
+	s := &Server{
+		subscriberMessageBuffer: 10,
+		subscribers:             make(map[*Subscriber]struct{}),
+		app:                     app,
+		platform:                handlers.CurrentPlatform(),
+		source:                  handlers.LocalSource(nil, cfg.DiskPath),
+		netTracker:              handlers.NewNetRateTracker(),
+		procTracker:             handlers.NewProcessTracker(),
+		swapTracker:             handlers.NewSwapTracker(),
+		history:                 metrics.NewHistory(cfg.HistorySize),
+		mountHistory:            metrics.NewMountHistory(cfg.HistorySize),
+		annotations:             metrics.NewAnnotations(),
+		diskTrends:              metrics.NewMountTrends(cfg.DiskTrendTicks),
+		cpuAverage:              metrics.NewEMA(cfg.CPUSmoothing),
+		store:                   store,
+		deltas:                  newDeltaLog(),
+		fleet:                   newFleet(cfg.FleetTargets),
+		reverseDNS:              newReverseDNS(),
+		refreshRequests:         make(chan chan refreshResult),
+	}
+	s.config.Store(cfg)
+	// ctx is cancelled on shutdown, stopping in-flight renders and streams
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	// Count requests for the monitor's own usage metrics
+	s.telemetry = telemetry.New(s.subscriberCount, cfg.HostLabel)
+	app.Use(s.telemetry.Middleware)
+
+	// Require credentials or a share link under --protect-dashboard
+	app.Use(s.dashboardAuth())
+
 	// WebSocket upgrade middleware
 	app.Use("/ws", func(c *fiber.Ctx) error {
 		if websocket.IsWebSocketUpgrade(c) {
 			c.Locals("allowed", true)
+			// The upgraded connection only sees headers under their
+			// canonical fasthttp names, so record the offer here
+			c.Locals("subprotocols", c.Get(fiber.HeaderSecWebSocketProtocol))
+			c.Locals("extensions", c.Get(fiber.HeaderSecWebSocketExtensions))
 			return c.Next()
 		}
 		return fiber.ErrUpgradeRequired
 	})
 
-	s := &Server{
-		subscriberMessageBuffer: 10,
-		subscribers:             make(map[*Subscriber]struct{}),
-		app:                     app,
+	if nodes, err := handlers.GetNUMAInfo(); err != nil {
+		console.Errorf("Error detecting NUMA nodes: %v", err)
+	} else {
+		s.hasNUMA = len(nodes) > 0
+	}
+
+	if pressure, err := handlers.GetPressureInfo(); err != nil {
+		console.Errorf("Error reading pressure stall information: %v", err)
+	} else {
+		s.hasPressure = pressure != nil
+	}
+
+	if fd, err := handlers.GetFDInfo(); err != nil {
+		console.Errorf("Error reading file descriptor usage: %v", err)
+	} else {
+		s.hasFD = fd != nil
+	}
+
+	// A configured server is always shown, reporting when it can't be
+	// reached; without one the clock panel needs the kernel's sync state
+	if cfg.NTPServer != "" {
+		s.hasClock = true
+	} else if clock, err := handlers.GetClockInfo("", ntpTimeout); err != nil {
+		console.Errorf("Error reading clock sync state: %v", err)
+	} else {
+		s.hasClock = clock != nil
+	}
+
+	s.sinks = s.newSinks(cfg)
+
+	if source != nil {
+		s.source = source
+	} else if cfg.SNMPTarget != "" {
+		if source, err := handlers.NewSNMPSource(cfg.SNMPTarget, cfg.SNMPCommunity, cfg.SNMPTimeout); err != nil {
+			console.Errorf("Error connecting to SNMP target %s: %v", cfg.SNMPTarget, err)
+		} else {
+			s.source = source
+			console.Printf(console.Remote, "Polling system, CPU and disk metrics from %s over SNMP", cfg.SNMPTarget)
+		}
+	} else if cfg.CPUSample > 0 {
+		// SNMP devices report their own CPU load, so only the local source
+		// is sampled
+		sampler := handlers.NewCPUSampler(cfg.CPUSample)
+		go sampler.Run(s.ctx)
+		s.source = handlers.LocalSource(sampler, cfg.DiskPath)
+		console.Printf(console.Timer, "Sampling CPU usage over %s windows", cfg.CPUSample)
 	}
 
+	if cfg.Docker {
+		if tracker, err := handlers.NewContainerTracker(); err != nil {
+			console.Errorf("Error creating Docker client: %v", err)
+		} else {
+			s.containerTracker = tracker
+		}
+	}
+
+	if cfg.SMART {
+		if s.hasSmartctl = handlers.SmartctlAvailable(); !s.hasSmartctl {
+			console.Errorf("Error enabling --smart: smartctl not found on PATH; install smartmontools for disk health")
+		}
+	}
+
+	s.collectors = s.newCollectors()
+
 	// Routes
+	app.Use("/static", filesystem.New(filesystem.Config{
+		Root:       http.FS(assets.Static),
+		PathPrefix: "static",
+		MaxAge:     3600,
+	}))
 	app.Get("/", s.indexHandler)
-	app.Get("/ws", websocket.New(s.websocketHandler))
+	app.Get("/ws", websocket.New(s.websocketHandler, websocket.Config{
+		Subprotocols:      subprotocols,
+		EnableCompression: cfg.WSCompression,
+	}))
+	app.Get("/events", s.eventsHandler)
+	app.Get("/compact", s.compactHandler)
+	app.Get("/version", s.versionHandler)
+	app.Get("/readyz", s.readyzHandler)
+	app.Get("/metrics", s.telemetry.Handler())
+	app.Get("/grafana-dashboard.json", s.grafanaDashboardHandler)
+	app.Get("/admin", s.adminAuth(false), s.adminHandler)
+	app.Get("/admin/usage", s.adminAuth(false), s.adminUsageHandler)
+	app.Get("/fleet", s.fleetHandler)
+	app.Get("/fleet/hosts", s.fleetHostsHandler)
+	app.Get("/fleet/host/:name", s.fleetHostHandler)
+
+	api := app.Group("/api")
+	if len(cfg.CORSOrigins) > 0 {
+		api.Use(cors.New(cors.Config{
+			AllowOrigins: strings.Join(cfg.CORSOrigins, ","),
+			AllowMethods: "GET,HEAD,OPTIONS",
+		}))
+	}
+	api.Get("/metrics", s.metricsHandler)
+	api.Get("/history", s.historyHandler)
+	api.Get("/annotations", s.annotationsHandler)
+	api.Post("/annotations", s.adminAuth(true), s.annotateHandler)
+	api.Get("/disk-report", s.diskReportHandler)
+	api.Get("/processes", s.processesHandler)
+	api.Get("/openapi.json", s.openAPIHandler)
+	api.Get("/subscribers", s.adminAuth(true), s.subscribersHandler)
+	api.Post("/refresh", s.adminAuth(true), s.refreshHandler)
+	api.Post("/share", s.adminAuth(true), s.shareHandler)
+	api.Get("/logs", s.adminAuth(true), s.logsHandler)
+	api.Get("/quiet", s.adminAuth(true), s.quietHandler)
+	api.Post("/quiet", s.adminAuth(true), s.quietStartHandler)
+	api.Delete("/quiet", s.adminAuth(true), s.quietEndHandler)
+	api.Use(func(c *fiber.Ctx) error {
+		return newAPIError(fiber.StatusNotFound, "not found", "no API endpoint at "+c.Path())
+	})
 
 	return s
 }
 
+// indexOptions returns the dashboard options matching the enabled panels
+func (s *Server) indexOptions() templates.IndexOptions {
+	// A replay shows the panels recorded, whatever collects locally
+	if s.replay != nil {
+		return snapshotOptions(&s.replay.snapshots[0])
+	}
+	c := s.collectors
+	return templates.IndexOptions{
+		ShowSystem:     c.system != nil,
+		ShowCPU:        c.cpu != nil,
+		ShowDisk:       c.disk != nil,
+		ShowNetwork:    c.network != nil,
+		ShowProcesses:  c.processes != nil,
+		ShowLoad:       c.load != nil,
+		ShowNUMA:       c.numa != nil,
+		ShowSwap:       c.swap != nil,
+		ShowPressure:   c.pressure != nil,
+		ShowFD:         c.fd != nil,
+		ShowClock:      c.clock != nil,
+		ShowContainers: c.docker != nil,
+		ShowDirs:       c.dirs != nil,
+		ShowDiskHealth: c.smart != nil,
+		ShowCustom:     len(c.custom) > 0,
+	}
+}
+
 func (s *Server) indexHandler(c *fiber.Ctx) error {
 	// Render the main page using templ
-	component := templates.Index()
+	opts := s.indexOptions()
+	opts.UseSSE = c.Query("transport") == "sse"
+	opts.AlertOnly = queryAlertOnly(c.Query(alertOnlyParam))
+	component := templates.Index(opts)
 
 	// Set content type to HTML
 	c.Set("Content-Type", "text/html")
 
 	// Render the component to HTML
 	var buf bytes.Buffer
-	err := component.Render(context.Background(), &buf)
+	err := component.Render(c.Context(), &buf)
 	if err != nil {
 		return err
 	}
@@ -77,29 +343,75 @@ func (s *Server) indexHandler(c *fiber.Ctx) error {
 }
 
 func (s *Server) websocketHandler(c *websocket.Conn) {
+	format, err := negotiateFormat(c.Locals("subprotocols").(string), c.Subprotocol(), c.Query("format"))
+	if err != nil {
+		console.Warnf("Rejecting WebSocket client: %v", err)
+		s.closeUnsupported(c, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
 	subscriber := &Subscriber{
-		msgs: make(chan []byte, s.subscriberMessageBuffer),
-		conn: c,
+		msgs:        make(chan streamMessage, s.subscriberMessageBuffer),
+		done:        make(chan struct{}),
+		conn:        c,
+		ctx:         ctx,
+		format:      format,
+		alertOnly:   queryAlertOnly(c.Query(alertOnlyParam)),
+		protocol:    c.Subprotocol(),
+		compressed:  s.getConfig().WSCompression && offersDeflate(c.Locals("extensions").(string)),
+		transport:   telemetry.TransportWebSocket,
+		remoteAddr:  c.RemoteAddr().String(),
+		connectedAt: time.Now(),
 	}
 
 	s.addSubscriber(subscriber)
 	defer s.removeSubscriber(subscriber)
 
-	fmt.Println("WebSocket connection established")
+	s.telemetry.StreamConnected(telemetry.TransportWebSocket)
+	defer s.telemetry.StreamDisconnected(telemetry.TransportWebSocket)
 
-	// Handle incoming messages and send outgoing messages
+	console.Infof("WebSocket connection established")
+
+	// Read client messages; this also processes pong control frames
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			s.handleClientMessage(subscriber, data)
+		}
+	}()
+	// The connection is pooled once the handler returns, so close it to end
+	// the read and wait for the reader to let go first
+	defer func() {
+		c.Close()
+		<-closed
+	}()
+
+	// Send outgoing messages
 	for {
 		select {
+		case <-closed:
+			return
+		case <-ctx.Done():
+			return
+		case <-subscriber.done:
+			return
 		case msg := <-subscriber.msgs:
-			err := c.WriteMessage(websocket.TextMessage, msg)
-			if err != nil {
-				fmt.Printf("WebSocket write error: %v\n", err)
+			if err := s.writeStreamMessage(c, msg); err != nil {
+				console.Infof("WebSocket write error: %v", err)
 				return
 			}
 		default:
 			// Check if connection is still alive
-			if err := c.WriteMessage(websocket.PingMessage, nil); err != nil {
-				fmt.Printf("WebSocket ping error: %v\n", err)
+			if err := s.writeMessage(c, websocket.PingMessage, nil); err != nil {
+				console.Infof("WebSocket ping error: %v", err)
 				return
 			}
 			time.Sleep(100 * time.Millisecond)
@@ -107,147 +419,493 @@ func (s *Server) websocketHandler(c *websocket.Conn) {
 	}
 }
 
+// clientMessage is a control message sent by the dashboard over the
+// websocket: an action, or a panel to send once with get
+type clientMessage struct {
+	Action string `json:"action"`
+	Get    string `json:"get"`
+}
+
+func (s *Server) handleClientMessage(subscriber *Subscriber, data []byte) {
+	var msg clientMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		console.Warnf("Invalid websocket message: %v", err)
+		return
+	}
+
+	// Collecting can take a while, so it runs off the read loop
+	if msg.Get != "" {
+		if subscriber.fetching.CompareAndSwap(false, true) {
+			go func() {
+				defer subscriber.fetching.Store(false)
+				s.sendOnDemand(subscriber, msg.Get)
+			}()
+		}
+		return
+	}
+	switch msg.Action {
+	case "pause":
+		s.setPaused(subscriber, true)
+	case "resume":
+		s.setPaused(subscriber, false)
+	default:
+		console.Warnf("Unknown websocket action %q", msg.Action)
+	}
+}
+
+// writeMessage writes one websocket message under the configured write
+// deadline. A client whose TCP buffer stays full fails the write with a
+// timeout, and the caller drops it instead of blocking forever.
+func (s *Server) writeMessage(c *websocket.Conn, messageType int, data []byte) error {
+	if timeout := s.getConfig().WSWriteTimeout; timeout > 0 {
+		if err := c.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+	}
+	return c.WriteMessage(messageType, data)
+}
+
+// writeStreamMessage writes a queued message, using the shared compressed
+// frame when there is one
+func (s *Server) writeStreamMessage(c *websocket.Conn, msg streamMessage) error {
+	if msg.prepared == nil {
+		return s.writeMessage(c, websocket.TextMessage, msg.data)
+	}
+	if timeout := s.getConfig().WSWriteTimeout; timeout > 0 {
+		if err := c.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+	}
+	return c.WritePreparedMessage(msg.prepared)
+}
+
+// setPaused pauses or resumes frame delivery to a subscriber and swaps the
+// stream control to match. Resuming immediately sends the latest frame.
+func (s *Server) setPaused(subscriber *Subscriber, paused bool) {
+	if subscriber.format != formatHTML {
+		s.subscribersMu.Lock()
+		defer s.subscribersMu.Unlock()
+		if _, ok := s.subscribers[subscriber]; !ok {
+			return
+		}
+		subscriber.paused = paused
+		if !paused {
+			s.sendLastFrame(subscriber)
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`<div hx-swap-oob="innerHTML:#stream-control">`)
+	if err := templates.StreamControl(paused).Render(subscriber.ctx, &buf); err != nil {
+		console.Errorf("Error rendering stream control: %v", err)
+		return
+	}
+	buf.WriteString(`</div>`)
+	if paused {
+		buf.WriteString(`<div hx-swap-oob="innerHTML:#update-timestamp">`)
+		if err := templates.StatusPaused().Render(subscriber.ctx, &buf); err != nil {
+			console.Errorf("Error rendering status component: %v", err)
+			return
+		}
+		buf.WriteString(`</div>`)
+	}
+
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+
+	// publishMsg may already have pruned the subscriber and ended its stream
+	if _, ok := s.subscribers[subscriber]; !ok {
+		return
+	}
+	subscriber.paused = paused
+
+	subscriber.send(streamMessage{data: buf.Bytes()})
+	if !paused {
+		s.sendLastFrame(subscriber)
+	}
+}
+
+// lastFrameFor returns the latest frame in the subscriber's format; callers
+// must hold subscribersMu
+func (s *Server) lastFrameFor(subscriber *Subscriber) []byte {
+	return s.lastFrames[subscriber.format]
+}
+
+// lastMessageFor returns the subscriber's variant of the latest frame;
+// callers must hold subscribersMu
+func (s *Server) lastMessageFor(subscriber *Subscriber) streamMessage {
+	return messageFor(subscriber, s.lastFrames, s.lastPrepared)
+}
+
+// sendLastFrame queues the latest frame in the subscriber's format,
+// reporting false before the first one; callers must hold subscribersMu
+func (s *Server) sendLastFrame(subscriber *Subscriber) bool {
+	if s.lastFrameFor(subscriber) == nil {
+		return false
+	}
+	subscriber.changedLevels(s.lastLevels)
+	subscriber.send(s.lastMessageFor(subscriber))
+	return true
+}
+
+// send queues msg without blocking, reporting false when the channel is
+// full. A removed subscriber silently drops the message.
+func (subscriber *Subscriber) send(msg streamMessage) bool {
+	select {
+	case <-subscriber.done:
+		return true
+	default:
+	}
+	select {
+	case subscriber.msgs <- msg:
+		return true
+	case <-subscriber.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// changedLevels records the health statuses of a frame about to be sent,
+// reporting whether they differ from the previous frame's
+func (subscriber *Subscriber) changedLevels(levels metrics.HealthLevels) bool {
+	subscriber.levelsMu.Lock()
+	defer subscriber.levelsMu.Unlock()
+	changed := subscriber.levels == nil || *subscriber.levels != levels
+	subscriber.levels = &levels
+	return changed
+}
+
+// close ends the subscriber's stream; callers must hold subscribersMu and
+// have removed it from the subscribers, so it runs once
+func (subscriber *Subscriber) close() {
+	close(subscriber.done)
+}
+
+func (s *Server) subscriberCount() int {
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	return len(s.subscribers)
+}
+
 func (s *Server) addSubscriber(subscriber *Subscriber) {
 	s.subscribersMu.Lock()
 	s.subscribers[subscriber] = struct{}{}
+	// Send the latest frame right away so (re)connecting clients don't wait
+	// for the next tick, or a starting-up notice during warm-up
+	if !s.sendLastFrame(subscriber) {
+		if starting := startingFrame(subscriber.ctx, subscriber.format); starting != nil {
+			subscriber.send(streamMessage{data: starting})
+		}
+	}
+	s.idle.resume()
+	total := len(s.subscribers)
 	s.subscribersMu.Unlock()
-	fmt.Printf("Added subscriber, total: %d\n", len(s.subscribers))
+	console.Infof("Added subscriber, total: %d", total)
 }
 
 func (s *Server) removeSubscriber(subscriber *Subscriber) {
 	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+
+	// publishMsg may already have pruned a slow subscriber
+	if _, ok := s.subscribers[subscriber]; !ok {
+		return
+	}
 	delete(s.subscribers, subscriber)
-	s.subscribersMu.Unlock()
-	fmt.Printf("Removed subscriber, total: %d\n", len(s.subscribers))
-	close(subscriber.msgs)
+	subscriber.close()
+	console.Infof("Removed subscriber, total: %d", len(s.subscribers))
+}
+
+// publishMsg sends every subscriber the frame in its format, skipping
+// alert-only subscribers when levels match the last frame they got, and
+// entirely during a maintenance window: they catch up on any change once it
+// ends. Only listing the subscribers holds subscribersMu; they are sent to
+// after releasing it, so connects, pauses and the admin list never wait on a
+// broadcast.
+func (s *Server) publishMsg(frames map[string][]byte, levels metrics.HealthLevels) {
+	prepared := s.prepareFrames(frames)
+	active := s.storeFrames(frames, prepared, levels)
+	s.broadcast(active, frames, prepared, levels)
 }
 
-func (s *Server) publishMsg(msg []byte) {
+// storeFrames records the frames sent to subscribers as they connect or
+// resume, and lists the unpaused subscribers to broadcast them to
+func (s *Server) storeFrames(frames map[string][]byte, prepared map[string]*fastws.PreparedMessage, levels metrics.HealthLevels) []*Subscriber {
 	s.subscribersMu.Lock()
 	defer s.subscribersMu.Unlock()
-
+	s.lastFrames = frames
+	s.lastPrepared = prepared
+	s.lastLevels = levels
+	active := make([]*Subscriber, 0, len(s.subscribers))
 	for subscriber := range s.subscribers {
-		select {
-		case subscriber.msgs <- msg:
-		default:
+		if !subscriber.paused {
+			active = append(active, subscriber)
+		}
+	}
+	return active
+}
+
+// broadcast sends the frames to the listed subscribers without holding
+// subscribersMu, dropping any whose channel is full
+func (s *Server) broadcast(active []*Subscriber, frames map[string][]byte, prepared map[string]*fastws.PreparedMessage, levels metrics.HealthLevels) {
+	quiet := s.quiet.active()
+	for _, subscriber := range active {
+		if subscriber.alertOnly && (quiet || !subscriber.changedLevels(levels)) {
+			continue
+		}
+		if !subscriber.send(messageFor(subscriber, frames, prepared)) {
 			// Channel is full, remove subscriber
-			fmt.Println("Subscriber channel full, removing subscriber")
-			delete(s.subscribers, subscriber)
-			close(subscriber.msgs)
+			console.Warnf("Subscriber channel full, removing subscriber")
+			s.dropSubscriber(subscriber)
 		}
 	}
 }
 
+// dropSubscriber removes a subscriber that fell behind, unless it has
+// disconnected meanwhile
+func (s *Server) dropSubscriber(subscriber *Subscriber) {
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	if _, ok := s.subscribers[subscriber]; !ok {
+		return
+	}
+	delete(s.subscribers, subscriber)
+	subscriber.close()
+}
+
 func (s *Server) startDataPublisher() {
+	s.idle = s.newIdleGate()
+	if s.idle != nil {
+		for _, r := range s.collectors.all() {
+			r.pauseWith(s.idle)
+		}
+	}
+	s.collectors.start()
+
 	go func() {
-		ticker := time.NewTicker(2 * time.Second)
-		defer ticker.Stop()
+		state := &publishState{
+			interval: s.getConfig().Interval,
+			warmup:   s.getConfig().WarmupTicks,
+			errors:   newErrorThrottle("collecting metrics"),
+		}
+		schedule := newPublishSchedule(state.interval, s.getConfig().AlignTicks)
+		defer schedule.stop()
 
-		for range ticker.C {
-			// Get system data
-			systemInfo, err := handlers.GetSystemInfo()
-			if err != nil {
-				fmt.Printf("Error getting system data: %v\n", err)
-				continue
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Shutting down: stop publishing to the streams being closed
+				return
+			case tick := <-schedule.C():
+				// Pick up an --interval changed by a reload
+				state.interval = s.getConfig().Interval
+				schedule.rearm(state.interval)
+				if s.pauseIfIdle() {
+					continue
+				}
+				state.report(s.publishTick(state, tick, false))
+			case <-s.idle.woken():
+				// A subscriber connected: refresh everything rather than
+				// publish the snapshot from before the pause
+				console.Println(console.Wake, "Subscriber connected, resuming collection")
+				s.collectors.refresh()
+				state.report(s.publishTick(state, time.Now(), true))
+			case reply := <-s.refreshRequests:
+				s.collectors.refresh()
+				err := s.publishTick(state, time.Now(), true)
+				_, seq := s.getLatestSeq()
+				reply <- refreshResult{seq: seq, err: err}
 			}
+		}
+	}()
+}
 
-			// Get disk data
-			diskInfo, err := handlers.GetDiskInfo()
-			if err != nil {
-				fmt.Printf("Error getting disk data: %v\n", err)
-				continue
-			}
+// publishState is the publisher's bookkeeping between ticks
+type publishState struct {
+	interval    time.Duration
+	lastPublish time.Time
+	warmup      int
+	// errors throttles the log lines of a publish failing every tick
+	errors *errorThrottle
+}
 
-			// Get CPU data
-			cpuInfo, err := handlers.GetCPUInfo()
-			if err != nil {
-				fmt.Printf("Error getting CPU data: %v\n", err)
-				continue
-			}
+// report logs a failed publish, throttled while the same error repeats;
+// warm-up is not a failure
+func (state *publishState) report(err error) {
+	switch {
+	case err == nil:
+		state.errors.cleared()
+	case !errors.Is(err, errStartingUp):
+		state.errors.failed(err)
+	}
+}
 
-			// Generate timestamp
-			timeStamp := time.Now().Format("2006-01-02 15:04:05")
-
-			// Render components to HTML
-			var systemBuf, diskBuf, cpuBuf, statusBuf bytes.Buffer
-
-			// Render system component
-			systemComponent := templates.SystemData(
-				systemInfo.OS,
-				systemInfo.Platform,
-				systemInfo.Hostname,
-				systemInfo.Procs,
-				systemInfo.TotalMem,
-				systemInfo.FreeMem,
-				systemInfo.UsedPercent,
-			)
-			err = systemComponent.Render(context.Background(), &systemBuf)
-			if err != nil {
-				fmt.Printf("Error rendering system component: %v\n", err)
-				continue
-			}
+// publishTick collects a snapshot, records it and broadcasts a frame unless
+// --publish-interval throttles it; forced refreshes are never throttled
+func (s *Server) publishTick(state *publishState, tick time.Time, forced bool) error {
+	snapshot, err := s.collectSnapshot()
+	if err != nil {
+		// Collectors still warming up are expected to be missing
+		if !s.ready.Load() {
+			return errStartingUp
+		}
+		return err
+	}
+	// Discard snapshots until every collector has real values
+	if !s.warmedUp(&state.warmup) {
+		return errStartingUp
+	}
+	s.publishSnapshot(state, snapshot, tick, forced)
+	return nil
+}
 
-			// Render disk component
-			diskComponent := templates.DiskData(
-				diskInfo.Total,
-				diskInfo.Used,
-				diskInfo.Free,
-				diskInfo.UsedPercent,
-			)
-			err = diskComponent.Render(context.Background(), &diskBuf)
-			if err != nil {
-				fmt.Printf("Error rendering disk component: %v\n", err)
-				continue
-			}
+// publishSnapshot records a snapshot and its history and hands it to the
+// sinks
+func (s *Server) publishSnapshot(state *publishState, snapshot *metrics.Snapshot, tick time.Time, forced bool) {
+	// Record the history, then the snapshot with its rolling aggregates,
+	// which include this sample
+	sample := snapshot.Sample()
+	s.history.Add(sample)
+	if window := s.getConfig().RollingWindow; window > 0 {
+		snapshot.Rolling = s.history.Rolling(window, snapshot.Time)
+	}
+	s.setLatest(snapshot)
+	var mountSamples []metrics.MountSample
+	if snapshot.Disk != nil {
+		s.diskTrends.Add(snapshot.Disk.Mounts)
+		mountSamples = metrics.MountSamples(snapshot.Time, snapshot.Disk.Mounts)
+		s.mountHistory.Add(mountSamples)
+	}
+	if s.store != nil {
+		if err := s.store.Insert(sample); err != nil {
+			console.Errorf("Error persisting history: %v", err)
+		}
+		if err := s.store.InsertMounts(mountSamples); err != nil {
+			console.Errorf("Error persisting disk history: %v", err)
+		}
+	}
 
-			// Render CPU component
-			cpuComponent := templates.CPUData(
-				cpuInfo.ModelName,
-				cpuInfo.Family,
-				cpuInfo.Mhz,
-				cpuInfo.Percentages,
-			)
-			// fmt.Println("Cpu percentage: ",cpuInfo.Percentages)
-			err = cpuComponent.Render(context.Background(), &cpuBuf)
-			if err != nil {
-				fmt.Printf("Error rendering CPU component: %v\n", err)
-				continue
-			}
+	// --publish-interval throttles frames without slowing collection,
+	// recording or the exports; skipped ticks are superseded by the next
+	// published one. Half a tick of slack absorbs ticker jitter so 1s ticks
+	// with a 3s throttle publish every third tick rather than every fourth.
+	throttled := !forced && tick.Sub(state.lastPublish) < s.getConfig().PublishInterval-state.interval/2
+	if !throttled {
+		state.lastPublish = tick
+	}
+	for _, sink := range s.sinks {
+		if sink.throttled && throttled {
+			continue
+		}
+		sink.Publish(snapshot)
+	}
+}
 
-			// Render status update component
-			statusComponent := templates.StatusUpdate(timeStamp)
-			err = statusComponent.Render(context.Background(), &statusBuf)
-			if err != nil {
-				fmt.Printf("Error rendering status component: %v\n", err)
-				continue
-			}
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	format.SetLocale(cfg.Locale)
+	format.SetUnits(cfg.Units)
+	console.Setup(cfg.NoEmoji)
+	logLevel.Set(cfg.LogLevel)
+	logger := slog.New(newLogHandler(cfg.LogFormat, os.Stdout))
+	console.SetLogger(logger)
+
+	// Benchmark mode times the collectors and exits without serving
+	if cfg.Benchmark {
+		NewServer(cfg, nil, logger).runBenchmark(cfg.BenchmarkRuns)
+		return
+	}
 
-			// Create HTMX-compatible message with hx-swap-oob
-			msg := []byte(fmt.Sprintf(`
-				<div hx-swap-oob="innerHTML:#update-timestamp">%s</div>
-				<div hx-swap-oob="innerHTML:#system-data">%s</div>
-				<div hx-swap-oob="innerHTML:#cpu-data">%s</div>
-				<div hx-swap-oob="innerHTML:#disk-data">%s</div>`,
-					statusBuf.String(),
-					systemBuf.String(),
-					cpuBuf.String(),
-					diskBuf.String()))
+	// Check mode rates one collection against the thresholds and exits with
+	// its status, for cron jobs and monitoring plugins. A --cpu-sample-interval
+	// sampler would have no reading yet, so CPU usage is measured directly.
+	if cfg.Check {
+		cfg.CPUSample = 0
+		os.Exit(NewServer(cfg, nil, logger).runCheck())
+	}
 
-				s.publishMsg(msg)
+	// TUI mode draws the panels in the terminal without serving
+	if cfg.TUI {
+		s := NewServer(cfg, nil, logger)
+		s.watchReload()
+		if err := s.runTUI(); err != nil {
+			log.Fatal(err)
 		}
-	}()
-}
+		return
+	}
 
-func main() {
-	fmt.Println("🚀 Starting GOTTH System Monitor on port 6080")
-	fmt.Println("📊 Stack: Go + Templ + Tailwind + HTMX")
+	// Headless mode prints metrics to the terminal without serving
+	if cfg.Headless {
+		s := NewServer(cfg, nil, logger)
+		s.watchReload()
+		s.runHeadless()
+		return
+	}
+
+	// Fan the log out to /api/logs, which needs the admin credentials
+	var logs *logHub
+	if cfg.AdminPassword != "" {
+		logs = newLogHub()
+		logger = slog.New(logs.handler(logger.Handler(), cfg.LogFormat))
+		console.SetLogger(logger)
+	}
+
+	if cfg.UnixSocket != "" {
+		console.Printf(console.Start, "Starting GOTTH System Monitor on unix socket %s", cfg.UnixSocket)
+	} else {
+		console.Println(console.Start, "Starting GOTTH System Monitor on port 6080")
+	}
+	console.Println(console.Stack, "Stack: Go + Templ + Tailwind + HTMX")
+
+	buildInfo := getBuildInfo()
+	console.Printf(console.Version, "Version %s (commit %s, built %s, %s)", buildInfo.Version, buildInfo.Commit, buildInfo.BuildDate, buildInfo.GoVersion)
+
+	var store *metrics.Store
+	if cfg.DBPath != "" {
+		retention := time.Duration(cfg.DBRetentionDays) * 24 * time.Hour
+		store, err = metrics.OpenStore(cfg.DBPath, retention)
+		if err != nil {
+			log.Fatalf("Error opening history database: %v", err)
+		}
+		defer store.Close()
+		console.Printf(console.Store, "Persisting history to %s (%d days retention)", cfg.DBPath, cfg.DBRetentionDays)
+	}
+
+	s := NewServer(cfg, store, logger)
+	s.logs = logs
+	s.watchReload()
 
-	s := NewServer()
+	if cfg.GRPCPort != 0 {
+		if err := s.startGRPC(cfg.GRPCPort); err != nil {
+			log.Fatalf("Error starting the gRPC server: %v", err)
+		}
+	}
 
-	// Start the data publisher goroutine
-	s.startDataPublisher()
+	// Start the data publisher goroutine, or play a recording back instead
+	if cfg.Replay != "" {
+		snapshots, err := metrics.ReadNDJSON(cfg.Replay)
+		if err != nil {
+			log.Fatalf("Error reading --replay recording %s: %v", cfg.Replay, err)
+		}
+		s.replay = &replay{snapshots: snapshots, speed: cfg.ReplaySpeed, loop: cfg.ReplayLoop}
+		s.startReplay()
+	} else {
+		s.startDataPublisher()
+	}
+	go s.watchSubscribers()
+	if len(cfg.FleetTargets) > 0 {
+		s.fleet.start(cfg.FleetInterval)
+		console.Printf(console.Fleet, "Polling %d fleet targets every %s", len(cfg.FleetTargets), cfg.FleetInterval)
+	}
 
-	// Start the server
-	log.Fatal(s.app.Listen(":6080"))
-	
+	// Start the server; it returns once a signal has shut it down
+	go s.shutdownOnSignal()
+	if err := s.listen(cfg.UnixSocket); err != nil {
+		log.Fatal(err)
+	}
 }
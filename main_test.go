@@ -0,0 +1,275 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+
+	fastws "github.com/fasthttp/websocket"
+)
+
+// fakeSource reports fixed system, CPU and disk readings
+type fakeSource struct{}
+
+func (fakeSource) GetSystemInfo() (*handlers.SystemInfo, error) {
+	return &handlers.SystemInfo{
+		OS:          "linux",
+		Platform:    "testos",
+		Hostname:    "test-host",
+		Procs:       42,
+		TotalMem:    8192,
+		FreeMem:     2048,
+		UsedPercent: 75,
+	}, nil
+}
+
+func (fakeSource) GetCPUInfo() (*handlers.CPUInfo, error) {
+	return &handlers.CPUInfo{ModelName: "Test CPU", Family: "6", Mhz: 2400, Percentages: []float64{10, 30}}, nil
+}
+
+func (fakeSource) GetDiskInfo() (*handlers.DiskInfo, error) {
+	mount := handlers.MountInfo{Mountpoint: "/", Device: "/dev/test", Fstype: "ext4", Total: 100 << 30, Used: 40 << 30, Free: 60 << 30, UsedPercent: 40}
+	return &handlers.DiskInfo{Path: "/", Total: mount.Total, Used: mount.Used, Free: mount.Free, UsedPercent: 40, Mounts: []handlers.MountInfo{mount}}, nil
+}
+
+// loadTestArgs returns args after the flags the tests share: the system,
+// CPU and disk panels only, published every 20ms from the first tick
+func loadTestArgs(args ...string) []string {
+	return append([]string{"--interval=20ms", "--panels=system,cpu,disk", "--warmup-ticks=0"}, args...)
+}
+
+// loadTestConfig parses args over the flags the tests share
+func loadTestConfig(args ...string) (*Config, error) {
+	return loadConfig(loadTestArgs(args...), flag.ContinueOnError)
+}
+
+// newTestServer builds a server reading fakeSource for the system, CPU and
+// disk panels, publishing every 20ms, and serves it on a local port. The
+// server is shut down when the test ends.
+func newTestServer(t *testing.T, args ...string) (*Server, string) {
+	t.Helper()
+	cfg, err := loadTestConfig(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newServer(cfg, nil, slog.New(slog.DiscardHandler), fakeSource{})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.app.Listener(ln)
+	t.Cleanup(s.shutdown)
+	return s, ln.Addr().String()
+}
+
+// waitForFrame waits until the publisher has published an HTML frame
+func waitForFrame(t *testing.T, s *Server) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.subscribersMu.Lock()
+		published := s.lastFrames[formatHTML] != nil
+		s.subscribersMu.Unlock()
+		if published {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("no frame published within 5s")
+}
+
+func TestWebSocketFirstFrame(t *testing.T) {
+	s, addr := newTestServer(t)
+	s.startDataPublisher()
+	waitForFrame(t, s)
+
+	conn, _, err := fastws.DefaultDialer.Dial("ws://"+addr+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, frame, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"#cpu-data", "#system-data", "#disk-data"} {
+		if !strings.Contains(string(frame), `hx-swap-oob="innerHTML:`+id+`"`) {
+			t.Errorf("first frame lacks %s:\n%s", id, frame)
+		}
+	}
+	if !strings.Contains(string(frame), "test-host") {
+		t.Errorf("first frame doesn't carry the fake source's readings:\n%s", frame)
+	}
+}
+
+// silenceLog discards the monitor's log lines for the rest of a benchmark
+func silenceLog(tb testing.TB) {
+	logger := console.Logger()
+	console.SetLogger(slog.New(slog.DiscardHandler))
+	tb.Cleanup(func() { console.SetLogger(logger) })
+}
+
+// newPublishServer returns a server with just what publishMsg needs
+func newPublishServer() *Server {
+	s := &Server{subscribers: make(map[*Subscriber]struct{})}
+	s.config.Store(&Config{})
+	return s
+}
+
+func newTestSubscriber(buffer int) *Subscriber {
+	return &Subscriber{
+		msgs:   make(chan streamMessage, buffer),
+		done:   make(chan struct{}),
+		format: formatHTML,
+	}
+}
+
+// TestShutdownWhilePublishing publishes frames, from the publisher and
+// directly, while subscribers disconnect and the server shuts down, as
+// happens when SIGTERM arrives mid-broadcast. Run under -race; a send on a
+// closed channel would panic.
+func TestShutdownWhilePublishing(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.startDataPublisher()
+
+	const count = 200
+	subscribers := make([]*Subscriber, count)
+	var readers sync.WaitGroup
+	for i := range subscribers {
+		subscriber := newTestSubscriber(s.subscriberMessageBuffer)
+		subscriber.ctx = s.ctx
+		subscribers[i] = subscriber
+		s.addSubscriber(subscriber)
+
+		// Drain like a stream handler until the subscriber is removed or
+		// the server shuts down
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-subscriber.done:
+					return
+				case <-subscriber.ctx.Done():
+					s.removeSubscriber(subscriber)
+					return
+				case <-subscriber.msgs:
+				}
+			}
+		}()
+	}
+
+	frames := map[string][]byte{formatHTML: []byte(`<div hx-swap-oob="innerHTML:#cpu-data">frame</div>`)}
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		<-start
+		for range 500 {
+			s.publishMsg(frames, metrics.HealthLevels{})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		<-start
+		for _, subscriber := range subscribers[:count/2] {
+			s.removeSubscriber(subscriber)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		<-start
+		time.Sleep(time.Millisecond)
+		s.shutdown()
+	}()
+	close(start)
+	wg.Wait()
+
+	stopped := make(chan struct{})
+	go func() {
+		readers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscriber streams still running 5s after shutdown")
+	}
+	if n := s.subscriberCount(); n != 0 {
+		t.Errorf("%d subscribers left after shutdown", n)
+	}
+
+	// Publishing after shutdown, as a tick already under way would, only
+	// drops the frames
+	s.publishMsg(frames, metrics.HealthLevels{})
+	for _, subscriber := range subscribers {
+		subscriber.send(streamMessage{data: frames[formatHTML]})
+	}
+}
+
+// BenchmarkPublishMsg runs publishMsg's steps against many subscribers,
+// half of them too slow to take another frame and so dropped and
+// reconnected on every tick. It reports how long each broadcast held
+// subscribersMu: only while listing the subscribers, a fraction of ns/op,
+// however slow they are.
+func BenchmarkPublishMsg(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("subscribers=%d", n), func(b *testing.B) {
+			silenceLog(b)
+			s := newPublishServer()
+			frames := map[string][]byte{formatHTML: []byte(`<div hx-swap-oob="innerHTML:#cpu-data">frame</div>`)}
+			levels := metrics.HealthLevels{}
+
+			subscribers := make([]*Subscriber, n)
+			slow := func(i int) bool { return i%2 == 1 }
+			connect := func(i int) {
+				subscriber := newTestSubscriber(1)
+				if slow(i) {
+					// A full channel, as a client stuck on a write leaves it
+					subscriber.msgs <- streamMessage{}
+				}
+				subscribers[i] = subscriber
+				s.subscribersMu.Lock()
+				s.subscribers[subscriber] = struct{}{}
+				s.subscribersMu.Unlock()
+			}
+			for i := range subscribers {
+				connect(i)
+			}
+
+			var held time.Duration
+			b.ResetTimer()
+			for range b.N {
+				prepared := s.prepareFrames(frames)
+				start := time.Now()
+				active := s.storeFrames(frames, prepared, levels)
+				held += time.Since(start)
+				s.broadcast(active, frames, prepared, levels)
+
+				b.StopTimer()
+				for i, subscriber := range subscribers {
+					if slow(i) {
+						connect(i)
+						continue
+					}
+					<-subscriber.msgs
+				}
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(held.Nanoseconds())/float64(b.N), "lock-held-ns/op")
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxAnnotations bounds the in-memory annotation log; the oldest are
+// dropped first
+const maxAnnotations = 1000
+
+// Annotation marks an event on the timeline, such as a deployment, so
+// charts can correlate it with the metrics around it
+type Annotation struct {
+	Time  time.Time `json:"timestamp"`
+	Label string    `json:"label"`
+}
+
+// Annotations keeps recent annotations in memory, ordered by time
+type Annotations struct {
+	mu          sync.RWMutex
+	annotations []Annotation
+}
+
+// NewAnnotations creates an empty annotation log
+func NewAnnotations() *Annotations {
+	return &Annotations{}
+}
+
+// Add records an annotation at its own time, which may be in the past
+func (a *Annotations) Add(annotation Annotation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i, _ := slices.BinarySearchFunc(a.annotations, annotation.Time, func(e Annotation, t time.Time) int {
+		return e.Time.Compare(t)
+	})
+	a.annotations = slices.Insert(a.annotations, i, annotation)
+	if len(a.annotations) > maxAnnotations {
+		a.annotations = a.annotations[len(a.annotations)-maxAnnotations:]
+	}
+}
+
+// Range returns the annotations within [from, to], oldest first
+func (a *Annotations) Range(from, to time.Time) []Annotation {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := []Annotation{}
+	for _, annotation := range a.annotations {
+		if annotation.Time.Before(from) || annotation.Time.After(to) {
+			continue
+		}
+		result = append(result, annotation)
+	}
+	return result
+}
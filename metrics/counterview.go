@@ -0,0 +1,60 @@
+package metrics
+
+import "slices"
+
+// Counter representations selectable with /api/metrics?counters= and on
+// the dashboard
+const (
+	CounterViewTotal = "total"
+	CounterViewRate  = "rate"
+	CounterViewBoth  = "both"
+)
+
+// CounterViews lists the accepted ?counters= values
+var CounterViews = []string{CounterViewTotal, CounterViewRate, CounterViewBoth}
+
+// WithCounterView returns a copy of the snapshot whose network and disk I/O
+// carry the cumulative counters since boot, the current rates, or both.
+// The half not asked for is zeroed, which leaves it out of the JSON. The
+// snapshot itself is not modified.
+func (s *Snapshot) WithCounterView(view string) *Snapshot {
+	if s == nil || view == CounterViewBoth || !slices.Contains(CounterViews, view) {
+		return s
+	}
+	totals, rates := view == CounterViewTotal, view == CounterViewRate
+
+	r := *s
+	if s.Network != nil {
+		network := *s.Network
+		network.Interfaces = slices.Clone(s.Network.Interfaces)
+		for i := range network.Interfaces {
+			iface := &network.Interfaces[i]
+			if rates {
+				iface.BytesSent, iface.BytesRecv = 0, 0
+			}
+			if totals {
+				iface.SentPerSec, iface.RecvPerSec = 0, 0
+			}
+		}
+		if rates {
+			network.Total.BytesSent, network.Total.BytesRecv = 0, 0
+		}
+		if totals {
+			network.Total.SentPerSec, network.Total.RecvPerSec = 0, 0
+		}
+		r.Network = &network
+	}
+	if s.Disk != nil && s.Disk.IO != nil {
+		disk := *s.Disk
+		io := *s.Disk.IO
+		if rates {
+			io.ReadBytes, io.WriteBytes = 0, 0
+		}
+		if totals {
+			io.ReadPerSec, io.WritePerSec = 0, 0
+		}
+		disk.IO = &io
+		r.Disk = &disk
+	}
+	return &r
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import "slices"
+
+// CPU representations selectable with /api/metrics?cpu=
+const (
+	CPUViewCores   = "cores"
+	CPUViewAverage = "avg"
+	CPUViewBoth    = "both"
+)
+
+// CPUViews lists the accepted ?cpu= values
+var CPUViews = []string{CPUViewCores, CPUViewAverage, CPUViewBoth}
+
+// WithCPUView returns a copy of the snapshot whose CPU panel carries the
+// per-core percentages, their average, or both. The average is the one the
+// dashboard and the history show. The snapshot itself is not modified.
+func (s *Snapshot) WithCPUView(view string) *Snapshot {
+	if s == nil || s.CPU == nil || !slices.Contains(CPUViews, view) {
+		return s
+	}
+
+	r := *s
+	cpu := *s.CPU
+	if view != CPUViewCores {
+		average := s.CPU.OverallUsage()
+		cpu.Average = &average
+	}
+	if view == CPUViewAverage {
+		cpu.Percentages = nil
+	}
+	r.CPU = &cpu
+	return &r
+}
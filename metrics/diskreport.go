@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// DiskReport summarizes per-mount usage over a time range for capacity
+// planning
+type DiskReport struct {
+	From   time.Time     `json:"from"`
+	To     time.Time     `json:"to"`
+	Mounts []MountReport `json:"mounts"`
+}
+
+// MountReport summarizes one mountpoint's usage over the report range.
+// DaysUntilFull is omitted when usage is flat or shrinking, or when there
+// are too few samples to project.
+type MountReport struct {
+	Mountpoint        string   `json:"mountpoint"`
+	Samples           int      `json:"samples"`
+	Total             uint64   `json:"total"`
+	Used              uint64   `json:"used"`
+	MinUsedPercent    float64  `json:"minUsedPercent"`
+	MaxUsedPercent    float64  `json:"maxUsedPercent"`
+	AvgUsedPercent    float64  `json:"avgUsedPercent"`
+	GrowthBytesPerDay float64  `json:"growthBytesPerDay"`
+	DaysUntilFull     *float64 `json:"daysUntilFull,omitempty"`
+}
+
+// NewDiskReport groups samples by mountpoint and summarizes each one.
+// Samples must be ordered oldest first; mounts are sorted by mountpoint.
+func NewDiskReport(from, to time.Time, samples []MountSample) DiskReport {
+	byMount := make(map[string][]MountSample)
+	for _, s := range samples {
+		byMount[s.Mountpoint] = append(byMount[s.Mountpoint], s)
+	}
+
+	report := DiskReport{From: from, To: to, Mounts: []MountReport{}}
+	for mountpoint, series := range byMount {
+		latest := series[len(series)-1]
+		mount := MountReport{
+			Mountpoint:     mountpoint,
+			Samples:        len(series),
+			Total:          latest.Total,
+			Used:           latest.Used,
+			MinUsedPercent: series[0].UsedPercent,
+			MaxUsedPercent: series[0].UsedPercent,
+		}
+
+		var sum float64
+		for _, s := range series {
+			mount.MinUsedPercent = min(mount.MinUsedPercent, s.UsedPercent)
+			mount.MaxUsedPercent = max(mount.MaxUsedPercent, s.UsedPercent)
+			sum += s.UsedPercent
+		}
+		mount.AvgUsedPercent = sum / float64(len(series))
+
+		if growth, ok := growthPerDay(series); ok {
+			mount.GrowthBytesPerDay = growth
+		}
+		if days, ok := ProjectDaysUntilFull(series); ok {
+			mount.DaysUntilFull = &days
+		}
+
+		report.Mounts = append(report.Mounts, mount)
+	}
+
+	sort.Slice(report.Mounts, func(i, j int) bool {
+		return report.Mounts[i].Mountpoint < report.Mounts[j].Mountpoint
+	})
+	return report
+}
+
+// ProjectDaysUntilFull fits a least-squares line through used bytes over
+// time and returns how many days after the last sample the line reaches the
+// mount's latest capacity. It reports false when there are fewer than two
+// samples spanning some time, or when usage is not growing.
+func ProjectDaysUntilFull(series []MountSample) (float64, bool) {
+	growth, ok := growthPerDay(series)
+	if !ok || growth <= 0 {
+		return 0, false
+	}
+
+	latest := series[len(series)-1]
+	if latest.Used >= latest.Total {
+		return 0, true
+	}
+	return float64(latest.Total-latest.Used) / growth, true
+}
+
+// growthPerDay returns the slope of the least-squares line through used
+// bytes over time, in bytes per day
+func growthPerDay(series []MountSample) (float64, bool) {
+	if len(series) < 2 {
+		return 0, false
+	}
+
+	// Measure time from the first sample to keep the sums well conditioned
+	origin := series[0].Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range series {
+		x := s.Time.Sub(origin).Hours() / 24
+		y := float64(s.Used)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(series))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denominator, true
+}
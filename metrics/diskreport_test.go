@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const gib = 1 << 30
+
+// dailySeries returns one sample per day of a mount with the given used
+// bytes, out of total
+func dailySeries(total uint64, used ...uint64) []MountSample {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := make([]MountSample, len(used))
+	for i, u := range used {
+		series[i] = MountSample{
+			Time:        start.Add(time.Duration(i) * 24 * time.Hour),
+			Mountpoint:  "/data",
+			Total:       total,
+			Used:        u,
+			UsedPercent: float64(u) / float64(total) * 100,
+		}
+	}
+	return series
+}
+
+func TestProjectDaysUntilFull(t *testing.T) {
+	sameTime := dailySeries(100*gib, 10*gib, 20*gib)
+	sameTime[1].Time = sameTime[0].Time
+
+	tests := []struct {
+		name     string
+		series   []MountSample
+		wantDays float64
+		wantOK   bool
+	}{
+		{"no samples", nil, 0, false},
+		{"one sample", dailySeries(100*gib, 10*gib), 0, false},
+		{"samples at one instant", sameTime, 0, false},
+		{"flat", dailySeries(100*gib, 50*gib, 50*gib, 50*gib), 0, false},
+		{"shrinking", dailySeries(100*gib, 60*gib, 55*gib, 50*gib), 0, false},
+		{"growing", dailySeries(100*gib, 40*gib, 50*gib, 60*gib), 4, true},
+		{"noisy growth", dailySeries(100*gib, 40*gib, 52*gib, 58*gib, 70*gib), 3.125, true},
+		{"already full", dailySeries(100*gib, 90*gib, 100*gib), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, ok := ProjectDaysUntilFull(tt.series)
+			if ok != tt.wantOK || math.Abs(days-tt.wantDays) > 1e-9 {
+				t.Errorf("ProjectDaysUntilFull = %v, %v; want %v, %v", days, ok, tt.wantDays, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewDiskReportGrowth(t *testing.T) {
+	series := append(dailySeries(100*gib, 40*gib, 50*gib, 60*gib), dailySeries(10*gib, 5*gib, 5*gib)...)
+	for i := 3; i < len(series); i++ {
+		series[i].Mountpoint = "/boot"
+	}
+	report := NewDiskReport(series[0].Time, series[2].Time, series)
+
+	if len(report.Mounts) != 2 {
+		t.Fatalf("got %d mounts, want 2", len(report.Mounts))
+	}
+	boot, data := report.Mounts[0], report.Mounts[1]
+	if boot.Mountpoint != "/boot" || boot.GrowthBytesPerDay != 0 || boot.DaysUntilFull != nil {
+		t.Errorf("flat mount = %+v, want no growth or projection", boot)
+	}
+	if data.Mountpoint != "/data" || data.GrowthBytesPerDay != 10*gib || data.DaysUntilFull == nil || *data.DaysUntilFull != 4 {
+		t.Errorf("growing mount = %+v, want 10 GiB a day and 4 days left", data)
+	}
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"time"
+)
+
+// Downsample averages samples into buckets of the given resolution. Buckets
+// are aligned to multiples of resolution (see time.Time.Truncate) and stamped
+// with their start time. Buckets at the edges of the range usually hold
+// fewer samples than interior ones; they are averaged over the samples they
+// actually contain, and Count reports how many that was. Samples must be
+// ordered oldest first.
+func Downsample(samples []Sample, resolution time.Duration) []Sample {
+	if resolution <= 0 {
+		return samples
+	}
+
+	result := []Sample{}
+	var bucket Sample
+	for _, sample := range samples {
+		start := sample.Time.Truncate(resolution)
+		if bucket.Count > 0 && !start.Equal(bucket.Time) {
+			result = append(result, bucket.mean())
+			bucket = Sample{}
+		}
+		if bucket.Count == 0 {
+			bucket.Time = start
+		}
+
+		bucket.CPUPercent += sample.CPUPercent
+		bucket.MemUsedPercent += sample.MemUsedPercent
+		bucket.DiskUsedPercent += sample.DiskUsedPercent
+		bucket.NetSentPerSec += sample.NetSentPerSec
+		bucket.NetRecvPerSec += sample.NetRecvPerSec
+		bucket.Count++
+	}
+	if bucket.Count > 0 {
+		result = append(result, bucket.mean())
+	}
+
+	return result
+}
+
+// mean converts a bucket holding summed values into averages
+func (s Sample) mean() Sample {
+	n := float64(s.Count)
+	s.CPUPercent /= n
+	s.MemUsedPercent /= n
+	s.DiskUsedPercent /= n
+	s.NetSentPerSec /= n
+	s.NetRecvPerSec /= n
+	return s
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDownsample(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration, cpu float64) Sample {
+		return Sample{Time: base.Add(offset), CPUPercent: cpu, MemUsedPercent: 2 * cpu}
+	}
+	bucket := func(offset time.Duration, cpu float64, count int) Sample {
+		return Sample{Time: base.Add(offset), CPUPercent: cpu, MemUsedPercent: 2 * cpu, Count: count}
+	}
+
+	tests := []struct {
+		name       string
+		samples    []Sample
+		resolution time.Duration
+		want       []Sample
+	}{
+		{"empty", nil, time.Minute, []Sample{}},
+		{
+			"starts and ends mid-bucket",
+			[]Sample{
+				at(30*time.Second, 10), at(40*time.Second, 20),
+				at(70*time.Second, 30), at(80*time.Second, 40), at(110*time.Second, 50),
+				at(130*time.Second, 60),
+			},
+			time.Minute,
+			[]Sample{bucket(0, 15, 2), bucket(time.Minute, 40, 3), bucket(2*time.Minute, 60, 1)},
+		},
+		{
+			"resolution larger than the range",
+			[]Sample{at(10*time.Minute, 10), at(15*time.Minute, 20), at(20*time.Minute, 60)},
+			time.Hour,
+			[]Sample{bucket(0, 30, 3)},
+		},
+		{
+			"range across a bucket boundary",
+			[]Sample{at(50*time.Minute, 10), at(70*time.Minute, 30)},
+			time.Hour,
+			[]Sample{bucket(0, 10, 1), bucket(time.Hour, 30, 1)},
+		},
+		{
+			"no resolution",
+			[]Sample{at(30*time.Second, 10), at(40*time.Second, 20)},
+			0,
+			[]Sample{at(30*time.Second, 10), at(40*time.Second, 20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Downsample(tt.samples, tt.resolution)
+			// An empty range encodes as [] rather than null
+			if got == nil {
+				t.Fatal("Downsample returned nil")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Downsample returned %d buckets, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				g := got[i]
+				if !g.Time.Equal(want.Time) || g.CPUPercent != want.CPUPercent || g.MemUsedPercent != want.MemUsedPercent || g.Count != want.Count {
+					t.Errorf("bucket %d = %+v, want %+v", i, g, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import "sync"
+
+// EMA is an exponential moving average. Each reading moves the average by
+// alpha of the distance to it, so a higher alpha follows spikes more closely
+// and a lower one shows sustained load.
+type EMA struct {
+	mu    sync.Mutex
+	alpha float64
+	value float64
+	ready bool
+}
+
+// NewEMA creates an average with smoothing factor alpha in (0, 1]
+func NewEMA(alpha float64) *EMA {
+	return &EMA{alpha: alpha}
+}
+
+// Add folds a reading into the average and returns the new value. The first
+// reading seeds the average.
+func (e *EMA) Add(v float64) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.ready {
+		e.value = v
+		e.ready = true
+	} else {
+		e.value += e.alpha * (v - e.value)
+	}
+	return e.value
+}
+
+// Value returns the current average, zero before the first reading
+func (e *EMA) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.value
+}
@@ -0,0 +1,125 @@
+package metrics
+
+import "system-monitor/handlers"
+
+// HealthStatus is the overall state shown by the health summary
+type HealthStatus int
+
+const (
+	HealthOK HealthStatus = iota
+	HealthWarning
+	HealthCritical
+)
+
+func (h HealthStatus) String() string {
+	switch h {
+	case HealthWarning:
+		return "warning"
+	case HealthCritical:
+		return "critical"
+	default:
+		return "ok"
+	}
+}
+
+// Threshold holds the percentages at which a metric turns yellow and red
+type Threshold struct {
+	Warning  float64
+	Critical float64
+}
+
+// Status rates a single value against the threshold
+func (t Threshold) Status(value float64) HealthStatus {
+	switch {
+	case value >= t.Critical:
+		return HealthCritical
+	case value >= t.Warning:
+		return HealthWarning
+	default:
+		return HealthOK
+	}
+}
+
+// HealthThresholds holds the per-metric thresholds for the health summary
+type HealthThresholds struct {
+	CPU    Threshold
+	Memory Threshold
+	Disk   Threshold
+}
+
+// DefaultHealthThresholds are used for metrics not set by --health-thresholds
+var DefaultHealthThresholds = HealthThresholds{
+	CPU:    Threshold{Warning: 75, Critical: 90},
+	Memory: Threshold{Warning: 80, Critical: 95},
+	Disk:   Threshold{Warning: 80, Critical: 90},
+}
+
+// Health is the result of evaluating a snapshot against the thresholds.
+// Metric and Value name the worst offending metric; Metric is empty when
+// everything is below its warning threshold.
+type Health struct {
+	Status HealthStatus
+	Metric string
+	Value  float64
+}
+
+// EvaluateHealth rates average CPU, memory and primary disk usage. The worst
+// offender is the metric with the highest status; ties go to the metric
+// furthest past its warning threshold. Nil inputs are skipped.
+func EvaluateHealth(system *handlers.SystemInfo, cpu *handlers.CPUInfo, disk *handlers.DiskInfo, thresholds HealthThresholds) Health {
+	type reading struct {
+		metric    string
+		value     float64
+		threshold Threshold
+	}
+	var readings []reading
+	if cpu != nil {
+		readings = append(readings, reading{"cpu", cpu.OverallUsage(), thresholds.CPU})
+	}
+	if system != nil {
+		readings = append(readings, reading{"memory", system.UsedPercent, thresholds.Memory})
+	}
+	if disk != nil {
+		readings = append(readings, reading{"disk", disk.UsedPercent, thresholds.Disk})
+	}
+
+	var health Health
+	var worstExcess float64
+	for _, r := range readings {
+		status := r.threshold.Status(r.value)
+		if status == HealthOK {
+			continue
+		}
+
+		excess := r.value - r.threshold.Warning
+		if status > health.Status || (status == health.Status && excess > worstExcess) {
+			health = Health{Status: status, Metric: r.metric, Value: r.value}
+			worstExcess = excess
+		}
+	}
+	return health
+}
+
+// HealthLevels rates each headline metric on its own, so a threshold
+// crossed or cleared is visible even when the worst offender is unchanged
+type HealthLevels struct {
+	CPU    HealthStatus
+	Memory HealthStatus
+	Disk   HealthStatus
+}
+
+// EvaluateLevels rates average CPU, memory and primary disk usage against
+// their thresholds. Nil inputs rate as ok.
+func EvaluateLevels(system *handlers.SystemInfo, cpu *handlers.CPUInfo, disk *handlers.DiskInfo, thresholds HealthThresholds) HealthLevels {
+	var levels HealthLevels
+	if cpu != nil {
+		levels.CPU = thresholds.CPU.Status(cpu.OverallUsage())
+	}
+	if system != nil {
+		levels.Memory = thresholds.Memory.Status(system.UsedPercent)
+	}
+	if disk != nil {
+		levels.Disk = thresholds.Disk.Status(disk.UsedPercent)
+	}
+	return levels
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"testing"
+
+	"system-monitor/handlers"
+)
+
+func TestEvaluateHealth(t *testing.T) {
+	cpu := func(percentages ...float64) *handlers.CPUInfo {
+		return &handlers.CPUInfo{Percentages: percentages}
+	}
+	system := func(used float64) *handlers.SystemInfo { return &handlers.SystemInfo{UsedPercent: used} }
+	disk := func(used float64) *handlers.DiskInfo { return &handlers.DiskInfo{UsedPercent: used} }
+
+	tests := []struct {
+		name   string
+		system *handlers.SystemInfo
+		cpu    *handlers.CPUInfo
+		disk   *handlers.DiskInfo
+		want   Health
+	}{
+		{"all ok", system(50), cpu(10, 20), disk(40), Health{}},
+		{"cpu warning", system(50), cpu(70, 80), disk(40), Health{HealthWarning, "cpu", 75}},
+		{"cpu critical", system(50), cpu(90, 100), disk(40), Health{HealthCritical, "cpu", 95}},
+		{"memory warning", system(80), cpu(10), disk(40), Health{HealthWarning, "memory", 80}},
+		{"memory critical", system(95), cpu(10), disk(40), Health{HealthCritical, "memory", 95}},
+		{"disk warning", system(50), cpu(10), disk(85), Health{HealthWarning, "disk", 85}},
+		{"disk critical", system(50), cpu(10), disk(90), Health{HealthCritical, "disk", 90}},
+		{"just below warning", system(79.9), cpu(74.9), disk(79.9), Health{}},
+		{"critical beats warning", system(94), cpu(90), disk(89), Health{HealthCritical, "cpu", 90}},
+		{"tie goes to furthest past warning", system(90), cpu(80), disk(85), Health{HealthWarning, "memory", 90}},
+		{"all nil", nil, nil, nil, Health{}},
+		{"disabled cpu", system(50), nil, disk(40), Health{}},
+		{"disabled memory and disk", nil, cpu(92), nil, Health{HealthCritical, "cpu", 92}},
+		{"cpu still collecting", system(50), cpu(), disk(40), Health{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateHealth(tt.system, tt.cpu, tt.disk, DefaultHealthThresholds)
+			if got != tt.want {
+				t.Errorf("EvaluateHealth = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateLevels(t *testing.T) {
+	tests := []struct {
+		name   string
+		system *handlers.SystemInfo
+		cpu    *handlers.CPUInfo
+		disk   *handlers.DiskInfo
+		want   HealthLevels
+	}{
+		{"mixed", &handlers.SystemInfo{UsedPercent: 85}, &handlers.CPUInfo{Percentages: []float64{91}}, &handlers.DiskInfo{UsedPercent: 10}, HealthLevels{CPU: HealthCritical, Memory: HealthWarning, Disk: HealthOK}},
+		{"all nil", nil, nil, nil, HealthLevels{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EvaluateLevels(tt.system, tt.cpu, tt.disk, DefaultHealthThresholds); got != tt.want {
+				t.Errorf("EvaluateLevels = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
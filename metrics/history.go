@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Sample is a single point-in-time reading of the headline metrics
+type Sample struct {
+	Time            time.Time `json:"time"`
+	CPUPercent      float64   `json:"cpuPercent"`
+	MemUsedPercent  float64   `json:"memUsedPercent"`
+	DiskUsedPercent float64   `json:"diskUsedPercent"`
+	NetSentPerSec   float64   `json:"netSentPerSec"`
+	NetRecvPerSec   float64   `json:"netRecvPerSec"`
+	// Count is the number of raw samples averaged into a downsampled bucket
+	Count int `json:"count,omitempty"`
+}
+
+// History is a fixed-size in-memory ring buffer of samples
+type History struct {
+	mu      sync.RWMutex
+	samples []Sample
+	next    int
+	full    bool
+}
+
+// NewHistory creates a ring buffer holding up to size samples
+func NewHistory(size int) *History {
+	return &History{
+		samples: make([]Sample, size),
+	}
+}
+
+// Add appends a sample, overwriting the oldest one when the buffer is full
+func (h *History) Add(sample Sample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) == 0 {
+		return
+	}
+
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Range returns the samples taken within [from, to], oldest first
+func (h *History) Range(from, to time.Time) []Sample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := []Sample{}
+	for _, sample := range h.ordered() {
+		if sample.Time.Before(from) || sample.Time.After(to) {
+			continue
+		}
+		result = append(result, sample)
+	}
+	return result
+}
+
+// ordered returns the buffered samples oldest first; callers must hold mu
+func (h *History) ordered() []Sample {
+	if !h.full {
+		return h.samples[:h.next]
+	}
+	return append(h.samples[h.next:len(h.samples):len(h.samples)], h.samples[:h.next]...)
+}
@@ -0,0 +1,269 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/handlers"
+)
+
+const (
+	influxRetries     = 2
+	influxRetryDelay  = 500 * time.Millisecond
+	influxTimeout     = 5 * time.Second
+	influxQueueSize   = 16
+	influxMaxPending  = 10000
+	influxPrecisionMs = "ms"
+)
+
+// InfluxConfig describes the InfluxDB write target
+type InfluxConfig struct {
+	// URL is the server base URL, e.g. http://localhost:8086
+	URL string
+	// Bucket is the v2 bucket, or "database/retention-policy" on 1.8+
+	Bucket string
+	Org    string
+	Token  string
+	// Tags are added to every point
+	Tags map[string]string
+}
+
+// InfluxWriter pushes snapshots to InfluxDB in line protocol over the v2
+// write API. Writes happen on a background goroutine; a tick's points are
+// sent as one batch, and points from failed writes are kept and resent
+// with the next batch.
+type InfluxWriter struct {
+	writeURL string
+	token    string
+	tags     string
+	client   *http.Client
+	batches  chan []string
+}
+
+// NewInfluxWriter validates the config and starts the background writer
+func NewInfluxWriter(cfg InfluxConfig) (*InfluxWriter, error) {
+	base, err := url.Parse(cfg.URL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid InfluxDB URL %q", cfg.URL)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("an InfluxDB bucket is required")
+	}
+
+	query := url.Values{}
+	query.Set("bucket", cfg.Bucket)
+	query.Set("precision", influxPrecisionMs)
+	if cfg.Org != "" {
+		query.Set("org", cfg.Org)
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/") + "/api/v2/write"
+	base.RawQuery = query.Encode()
+
+	w := &InfluxWriter{
+		writeURL: base.String(),
+		token:    cfg.Token,
+		tags:     influxTagSet(cfg.Tags),
+		client:   &http.Client{Timeout: influxTimeout},
+		batches:  make(chan []string, influxQueueSize),
+	}
+	go w.run()
+
+	return w, nil
+}
+
+// Publish queues the snapshot's points without blocking the publisher. If the
+// writer has fallen behind, the snapshot is dropped.
+func (w *InfluxWriter) Publish(snapshot *Snapshot) {
+	select {
+	case w.batches <- w.lines(snapshot):
+	default:
+		console.Errorf("Error writing to InfluxDB: queue full, dropping snapshot")
+	}
+}
+
+func (w *InfluxWriter) run() {
+	var pending []string
+	for batch := range w.batches {
+		pending = append(pending, batch...)
+		if len(pending) > influxMaxPending {
+			pending = pending[len(pending)-influxMaxPending:]
+		}
+
+		if err := w.post(pending); err != nil {
+			console.Errorf("Error writing to InfluxDB (%d points kept for retry): %v", len(pending), err)
+			continue
+		}
+		pending = pending[:0]
+	}
+}
+
+// post sends the lines, retrying briefly on network errors and 5xx/429
+// responses. Other client errors are not retried, and the lines are dropped
+// since resending them cannot succeed.
+func (w *InfluxWriter) post(lines []string) error {
+	body := []byte(strings.Join(lines, "\n"))
+
+	var err error
+	for attempt := 0; attempt <= influxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(influxRetryDelay * time.Duration(attempt))
+		}
+
+		var retry bool
+		retry, err = w.send(body)
+		if err == nil || !retry {
+			break
+		}
+	}
+	if err != nil {
+		var permanent *influxRejectedError
+		if errors.As(err, &permanent) {
+			console.Errorf("Error writing to InfluxDB, dropping %d points: %v", len(lines), err)
+			return nil
+		}
+	}
+	return err
+}
+
+// influxRejectedError is a write the server refused for good
+type influxRejectedError struct {
+	status string
+	body   string
+}
+
+func (e *influxRejectedError) Error() string {
+	return e.status + ": " + e.body
+}
+
+func (w *InfluxWriter) send(body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.writeURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if w.token != "" {
+		req.Header.Set("Authorization", "Token "+w.token)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return false, &influxRejectedError{status: resp.Status, body: strings.TrimSpace(string(msg))}
+}
+
+// lines renders a snapshot as line protocol points
+func (w *InfluxWriter) lines(s *Snapshot) []string {
+	ts := strconv.FormatInt(s.Time.UnixMilli(), 10)
+	var lines []string
+	point := func(measurement, tags, fields string) {
+		lines = append(lines, measurement+w.tags+tags+" "+fields+" "+ts)
+	}
+
+	if s.CPU != nil {
+		point("cpu", ",cpu=cpu-total", "usage_percent="+influxFloat(s.CPU.OverallUsage()))
+		for i, percent := range s.CPU.Percentages {
+			point("cpu", ",cpu=cpu"+strconv.Itoa(i), "usage_percent="+influxFloat(percent))
+		}
+	}
+
+	if s.System != nil {
+		point("mem", "", fmt.Sprintf("total_mb=%di,free_mb=%di,used_percent=%s",
+			s.System.TotalMem, s.System.FreeMem, influxFloat(s.System.UsedPercent)))
+		point("system", "", fmt.Sprintf("procs=%di", s.System.Procs))
+	}
+
+	if s.Disk != nil {
+		for _, m := range s.Disk.Mounts {
+			point("disk", ",path="+influxEscape(m.Mountpoint), fmt.Sprintf("total=%di,used=%di,free=%di,used_percent=%s",
+				m.Total, m.Used, m.Free, influxFloat(m.UsedPercent)))
+		}
+	}
+
+	if s.Network != nil {
+		for _, iface := range s.Network.Interfaces {
+			point("net", ",interface="+influxEscape(iface.Name), fmt.Sprintf("bytes_sent=%di,bytes_recv=%di,sent_per_sec=%s,recv_per_sec=%s",
+				iface.BytesSent, iface.BytesRecv, influxFloat(iface.SentPerSec), influxFloat(iface.RecvPerSec)))
+		}
+	}
+
+	if swap := s.Swap; swap != nil {
+		fields := fmt.Sprintf("total=%di,used=%di,used_percent=%s", swap.Total, swap.Used, influxFloat(swap.UsedPercent))
+		if swap.PagesInPerSec != nil && swap.PagesOutPerSec != nil {
+			fields += ",pages_in_per_sec=" + influxFloat(*swap.PagesInPerSec) + ",pages_out_per_sec=" + influxFloat(*swap.PagesOutPerSec)
+		}
+		point("swap", "", fields)
+	}
+
+	if p := s.Pressure; p != nil {
+		for _, r := range []struct {
+			name     string
+			pressure handlers.ResourcePressure
+		}{{"cpu", p.CPU}, {"memory", p.Memory}, {"io", p.IO}} {
+			point("pressure", ",resource="+r.name+",kind=some", influxStall(r.pressure.Some))
+			if r.pressure.Full != nil {
+				point("pressure", ",resource="+r.name+",kind=full", influxStall(*r.pressure.Full))
+			}
+		}
+	}
+
+	if s.Load != nil {
+		point("load", "", fmt.Sprintf("load1=%s,load5=%s,load15=%s",
+			influxFloat(s.Load.Load1), influxFloat(s.Load.Load5), influxFloat(s.Load.Load15)))
+	}
+
+	return lines
+}
+
+// influxTagSet renders tags sorted by key, as line protocol recommends
+func influxTagSet(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		if tags[k] == "" {
+			continue
+		}
+		b.WriteString("," + influxEscape(k) + "=" + influxEscape(tags[k]))
+	}
+	return b.String()
+}
+
+var influxEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// influxEscape escapes a tag key or value
+func influxEscape(s string) string {
+	return influxEscaper.Replace(s)
+}
+
+func influxFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func influxStall(stall handlers.PressureStall) string {
+	return "avg10=" + influxFloat(stall.Avg10) + ",avg60=" + influxFloat(stall.Avg60) + ",avg300=" + influxFloat(stall.Avg300)
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"system-monitor/handlers"
+)
+
+// MountSample is one mountpoint's usage at a point in time
+type MountSample struct {
+	Time        time.Time `json:"time"`
+	Mountpoint  string    `json:"mountpoint"`
+	Total       uint64    `json:"total"`
+	Used        uint64    `json:"used"`
+	UsedPercent float64   `json:"usedPercent"`
+}
+
+// MountSamples flattens the mounts collected at t into samples
+func MountSamples(t time.Time, mounts []handlers.MountInfo) []MountSample {
+	samples := make([]MountSample, 0, len(mounts))
+	for _, m := range mounts {
+		samples = append(samples, MountSample{
+			Time:        t,
+			Mountpoint:  m.Mountpoint,
+			Total:       m.Total,
+			Used:        m.Used,
+			UsedPercent: m.UsedPercent,
+		})
+	}
+	return samples
+}
+
+// MountHistory is a fixed-size in-memory ring buffer of per-mount usage,
+// holding one entry per tick like History
+type MountHistory struct {
+	mu    sync.RWMutex
+	ticks [][]MountSample
+	next  int
+	full  bool
+}
+
+// NewMountHistory creates a ring buffer holding up to size ticks
+func NewMountHistory(size int) *MountHistory {
+	return &MountHistory{
+		ticks: make([][]MountSample, size),
+	}
+}
+
+// Add records the samples of one tick, overwriting the oldest tick when the
+// buffer is full
+func (h *MountHistory) Add(samples []MountSample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.ticks) == 0 {
+		return
+	}
+
+	h.ticks[h.next] = samples
+	h.next = (h.next + 1) % len(h.ticks)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Range returns the samples taken within [from, to], oldest first
+func (h *MountHistory) Range(from, to time.Time) []MountSample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ticks := h.ticks[:h.next]
+	if h.full {
+		ticks = append(h.ticks[h.next:len(h.ticks):len(h.ticks)], h.ticks[:h.next]...)
+	}
+
+	result := []MountSample{}
+	for _, samples := range ticks {
+		for _, sample := range samples {
+			if sample.Time.Before(from) || sample.Time.After(to) {
+				continue
+			}
+			result = append(result, sample)
+		}
+	}
+	return result
+}
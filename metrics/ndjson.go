@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+
+	"system-monitor/console"
+)
+
+// ndjsonQueueSize bounds the snapshots waiting to be written
+const ndjsonQueueSize = 16
+
+// NDJSONWriter appends every snapshot to a file as one JSON object per
+// line. Writes happen on a background goroutine.
+type NDJSONWriter struct {
+	file      *os.File
+	snapshots chan Snapshot
+}
+
+// NewNDJSONWriter opens path for appending, creating it if needed, and
+// starts the background writer
+func NewNDJSONWriter(path string) (*NDJSONWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	w := &NDJSONWriter{
+		file:      file,
+		snapshots: make(chan Snapshot, ndjsonQueueSize),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Publish queues the snapshot without blocking the publisher. If the writer
+// has fallen behind, the snapshot is dropped.
+func (w *NDJSONWriter) Publish(snapshot *Snapshot) {
+	select {
+	case w.snapshots <- *snapshot:
+	default:
+		console.Errorf("Error writing NDJSON: queue full, dropping snapshot")
+	}
+}
+
+func (w *NDJSONWriter) run() {
+	encoder := json.NewEncoder(w.file)
+	for snapshot := range w.snapshots {
+		if err := encoder.Encode(&snapshot); err != nil {
+			console.Errorf("Error writing NDJSON to %s: %v", w.file.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"system-monitor/console"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// pushGatewayJob is the job label the gauges are grouped under
+const pushGatewayJob = "system_monitor"
+
+// Gauges pushed to the Pushgateway, also referenced by the Grafana dashboard
+const (
+	GaugeCPUPercent    = "monitor_cpu_percent"
+	GaugeMemoryPercent = "monitor_memory_used_percent"
+	GaugeDiskPercent   = "monitor_disk_used_percent"
+	GaugeSwapPercent   = "monitor_swap_used_percent"
+	GaugeLoad1         = "monitor_load1"
+)
+
+// PushGateway pushes the headline metrics to a Prometheus Pushgateway, for
+// hosts Prometheus cannot scrape. Pushes happen on a background goroutine;
+// a snapshot still waiting when the next one arrives is superseded by it,
+// since only the latest values matter.
+type PushGateway struct {
+	url      string
+	instance string
+	latest   chan *Snapshot
+}
+
+// NewPushGateway starts pushing to the Pushgateway at url, grouping the
+// gauges by the instance label
+func NewPushGateway(url, instance string) *PushGateway {
+	p := &PushGateway{
+		url:      url,
+		instance: instance,
+		latest:   make(chan *Snapshot, 1),
+	}
+	go p.run()
+	return p
+}
+
+// Publish replaces any snapshot still waiting to be pushed
+func (p *PushGateway) Publish(snapshot *Snapshot) {
+	select {
+	case <-p.latest:
+	default:
+	}
+	p.latest <- snapshot
+}
+
+func (p *PushGateway) run() {
+	for snapshot := range p.latest {
+		if err := p.push(snapshot); err != nil {
+			console.Errorf("Error pushing to Pushgateway: %v", err)
+		}
+	}
+}
+
+// push replaces the instance's group with gauges for the metrics the
+// snapshot has, so a disabled panel leaves no stale gauge behind
+func (p *PushGateway) push(snapshot *Snapshot) error {
+	pusher := push.New(p.url, pushGatewayJob).Grouping("instance", p.instance)
+	gauge := func(name, help string, value float64) {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: help})
+		g.Set(value)
+		pusher.Collector(g)
+	}
+
+	sample := snapshot.Sample()
+	if snapshot.CPU != nil {
+		gauge(GaugeCPUPercent, "Average CPU usage across cores", sample.CPUPercent)
+	}
+	if snapshot.System != nil {
+		gauge(GaugeMemoryPercent, "Used memory percentage", sample.MemUsedPercent)
+	}
+	if snapshot.Disk != nil {
+		gauge(GaugeDiskPercent, "Used percentage of the primary disk", sample.DiskUsedPercent)
+	}
+	if snapshot.Swap != nil {
+		gauge(GaugeSwapPercent, "Used swap percentage", snapshot.Swap.UsedPercent)
+	}
+	if snapshot.Load != nil {
+		gauge(GaugeLoad1, "One minute load average", snapshot.Load.Load1)
+	}
+	return pusher.Push()
+}
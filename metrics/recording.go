@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadNDJSON reads the snapshots an NDJSONWriter recorded to path, in
+// order. A truncated last line, as left by a monitor killed mid-write, is
+// skipped.
+func ReadNDJSON(path string) ([]Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snapshots []Snapshot
+	decoder := json.NewDecoder(file)
+	for {
+		var snapshot Snapshot
+		err := decoder.Decode(&snapshot)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %d: %w", len(snapshots)+1, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.New("no snapshots recorded")
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import "time"
+
+// RollingStats summarizes the headline usage over the trailing
+// --rolling-window, catching spikes the instantaneous gauges miss between
+// glances
+type RollingStats struct {
+	// Seconds is the span the samples cover: the whole window, or less when
+	// Partial
+	Seconds float64 `json:"seconds"`
+	// Partial is set while the history doesn't reach back the whole window,
+	// because it is still filling or --history-size holds less than that
+	Partial bool    `json:"partial,omitempty"`
+	Samples int     `json:"samples"`
+	CPUPeak float64 `json:"cpuPeak"`
+	CPUAvg  float64 `json:"cpuAvg"`
+	MemPeak float64 `json:"memPeak"`
+	MemAvg  float64 `json:"memAvg"`
+}
+
+// Rolling summarizes the samples taken in the window ending at now, or
+// returns nil when there are none
+func (h *History) Rolling(window time.Duration, now time.Time) *RollingStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	start := now.Add(-window)
+	stats := &RollingStats{Partial: true}
+	var oldest time.Time
+	var cpuSum, memSum float64
+	for _, sample := range h.ordered() {
+		if !sample.Time.After(start) {
+			// An older sample means the window is covered in full
+			stats.Partial = false
+			continue
+		}
+		if sample.Time.After(now) {
+			continue
+		}
+		if stats.Samples == 0 {
+			oldest = sample.Time
+		}
+		stats.Samples++
+		cpuSum += sample.CPUPercent
+		memSum += sample.MemUsedPercent
+		stats.CPUPeak = max(stats.CPUPeak, sample.CPUPercent)
+		stats.MemPeak = max(stats.MemPeak, sample.MemUsedPercent)
+	}
+	if stats.Samples == 0 {
+		return nil
+	}
+
+	stats.CPUAvg = cpuSum / float64(stats.Samples)
+	stats.MemAvg = memSum / float64(stats.Samples)
+	stats.Seconds = window.Seconds()
+	if stats.Partial {
+		stats.Seconds = now.Sub(oldest).Seconds()
+	}
+	return stats
+}
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+)
+
+// Round rounds v to decimals places; a negative decimals leaves v as is
+func Round(v float64, decimals int) float64 {
+	if decimals < 0 {
+		return v
+	}
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
+
+// Rounded returns a copy of the snapshot with every percentage and load
+// average rounded to decimals places, for JSON consumers. The snapshot
+// itself is not modified, so history and thresholds keep full precision. A
+// negative decimals returns the snapshot unchanged.
+func (s *Snapshot) Rounded(decimals int) *Snapshot {
+	if s == nil || decimals < 0 {
+		return s
+	}
+	round := func(v float64) float64 { return Round(v, decimals) }
+
+	r := *s
+	r.CPUAverage = round(s.CPUAverage)
+	if s.Rolling != nil {
+		rolling := *s.Rolling
+		rolling.CPUPeak = round(rolling.CPUPeak)
+		rolling.CPUAvg = round(rolling.CPUAvg)
+		rolling.MemPeak = round(rolling.MemPeak)
+		rolling.MemAvg = round(rolling.MemAvg)
+		r.Rolling = &rolling
+	}
+	if s.System != nil {
+		system := *s.System
+		system.UsedPercent = round(system.UsedPercent)
+		r.System = &system
+	}
+	if s.Disk != nil {
+		disk := *s.Disk
+		disk.UsedPercent = round(disk.UsedPercent)
+		disk.Mounts = slices.Clone(s.Disk.Mounts)
+		for i := range disk.Mounts {
+			disk.Mounts[i].UsedPercent = round(disk.Mounts[i].UsedPercent)
+		}
+		r.Disk = &disk
+	}
+	if s.CPU != nil {
+		cpu := *s.CPU
+		cpu.Percentages = slices.Clone(s.CPU.Percentages)
+		for i := range cpu.Percentages {
+			cpu.Percentages[i] = round(cpu.Percentages[i])
+		}
+		if s.CPU.Average != nil {
+			average := round(*s.CPU.Average)
+			cpu.Average = &average
+		}
+		if s.CPU.Steal != nil {
+			steal := round(*s.CPU.Steal)
+			cpu.Steal = &steal
+		}
+		r.CPU = &cpu
+	}
+	r.NUMA = slices.Clone(s.NUMA)
+	for i := range r.NUMA {
+		r.NUMA[i].UsedPercent = round(r.NUMA[i].UsedPercent)
+	}
+	if s.Load != nil {
+		load := *s.Load
+		load.Load1 = round(load.Load1)
+		load.Load5 = round(load.Load5)
+		load.Load15 = round(load.Load15)
+		r.Load = &load
+	}
+	if s.Swap != nil {
+		swap := *s.Swap
+		swap.UsedPercent = round(swap.UsedPercent)
+		r.Swap = &swap
+	}
+	r.Containers = slices.Clone(s.Containers)
+	for i := range r.Containers {
+		r.Containers[i].CPUPercent = round(r.Containers[i].CPUPercent)
+		r.Containers[i].MemPercent = round(r.Containers[i].MemPercent)
+	}
+	if s.Processes != nil {
+		processes := *s.Processes
+		processes.Processes = slices.Clone(s.Processes.Processes)
+		for i := range processes.Processes {
+			processes.Processes[i].CPUPercent = round(processes.Processes[i].CPUPercent)
+			processes.Processes[i].MemPercent = round(processes.Processes[i].MemPercent)
+		}
+		processes.Groups = slices.Clone(s.Processes.Groups)
+		for i := range processes.Groups {
+			g := &processes.Groups[i]
+			g.CPUPercent = round(g.CPUPercent)
+			g.MemPercent = round(g.MemPercent)
+			g.Processes = slices.Clone(g.Processes)
+			for j := range g.Processes {
+				g.Processes[j].CPUPercent = round(g.Processes[j].CPUPercent)
+				g.Processes[j].MemPercent = round(g.Processes[j].MemPercent)
+			}
+		}
+		r.Processes = &processes
+	}
+	return &r
+}
+
+// RoundSamples rounds the percentages of samples in place
+func RoundSamples(samples []Sample, decimals int) {
+	for i := range samples {
+		samples[i].CPUPercent = Round(samples[i].CPUPercent, decimals)
+		samples[i].MemUsedPercent = Round(samples[i].MemUsedPercent, decimals)
+		samples[i].DiskUsedPercent = Round(samples[i].DiskUsedPercent, decimals)
+	}
+}
+
+// Round rounds the report's usage percentages in place
+func (r *DiskReport) Round(decimals int) {
+	for i := range r.Mounts {
+		m := &r.Mounts[i]
+		m.MinUsedPercent = Round(m.MinUsedPercent, decimals)
+		m.MaxUsedPercent = Round(m.MaxUsedPercent, decimals)
+		m.AvgUsedPercent = Round(m.AvgUsedPercent, decimals)
+	}
+}
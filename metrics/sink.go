@@ -0,0 +1,9 @@
+package metrics
+
+// Sink is an output of the publisher. Publish is called with every
+// snapshot from the publisher goroutine; a sink that talks to another
+// process must hand the snapshot off rather than block collection, and
+// reports its own errors.
+type Sink interface {
+	Publish(snapshot *Snapshot)
+}
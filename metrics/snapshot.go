@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"time"
+
+	"system-monitor/handlers"
+)
+
+// Snapshot holds everything collected during one publisher tick. Panels
+// disabled with --panels are nil, as are those of the collectors in Denied,
+// which the monitor lacked the privileges to read.
+type Snapshot struct {
+	Time   time.Time            `json:"time"`
+	System *handlers.SystemInfo `json:"system,omitempty"`
+	Disk   *handlers.DiskInfo   `json:"disk,omitempty"`
+	CPU    *handlers.CPUInfo    `json:"cpu,omitempty"`
+	// CPUAverage is the exponential moving average of overall CPU usage
+	// across ticks, smoothed by --cpu-smoothing
+	CPUAverage float64                  `json:"cpuAverage"`
+	Network    *handlers.NetworkInfo    `json:"network,omitempty"`
+	Load       *handlers.LoadInfo       `json:"load,omitempty"`
+	NUMA       []handlers.NUMANodeInfo  `json:"numa,omitempty"`
+	Swap       *handlers.SwapInfo       `json:"swap,omitempty"`
+	Pressure   *handlers.PressureInfo   `json:"pressure,omitempty"`
+	FD         *handlers.FDInfo         `json:"fd,omitempty"`
+	Clock      *handlers.ClockInfo      `json:"clock,omitempty"`
+	Containers []handlers.ContainerStat `json:"containers,omitempty"`
+	Dirs       []handlers.DirInfo       `json:"dirs,omitempty"`
+	DiskHealth []handlers.DiskHealth    `json:"diskHealth,omitempty"`
+	Custom     []handlers.CustomMetric  `json:"custom,omitempty"`
+	Processes  *handlers.ProcessInfo    `json:"processes,omitempty"`
+	Denied     []string                 `json:"denied,omitempty"`
+	// Rolling is the peak and average usage over --rolling-window
+	Rolling *RollingStats `json:"rolling,omitempty"`
+}
+
+// Sample reduces the snapshot to its headline metrics for the history.
+// Metrics of disabled panels are zero.
+func (s *Snapshot) Sample() Sample {
+	sample := Sample{Time: s.Time}
+	if s.CPU != nil {
+		sample.CPUPercent = s.CPU.OverallUsage()
+	}
+	if s.System != nil {
+		sample.MemUsedPercent = s.System.UsedPercent
+	}
+	if s.Disk != nil {
+		sample.DiskUsedPercent = s.Disk.UsedPercent
+	}
+	if s.Network != nil {
+		sample.NetSentPerSec = s.Network.Total.SentPerSec
+		sample.NetRecvPerSec = s.Network.Total.RecvPerSec
+	}
+	return sample
+}
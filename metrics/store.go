@@ -0,0 +1,250 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const pruneInterval = time.Hour
+
+const schema = `
+CREATE TABLE IF NOT EXISTS samples (
+	ts                INTEGER NOT NULL,
+	cpu_percent       REAL NOT NULL,
+	mem_used_percent  REAL NOT NULL,
+	disk_used_percent REAL NOT NULL,
+	net_sent_per_sec  REAL NOT NULL,
+	net_recv_per_sec  REAL NOT NULL
+);
+CREATE INDEX IF NOT EXISTS samples_ts ON samples (ts);
+CREATE TABLE IF NOT EXISTS mount_samples (
+	ts           INTEGER NOT NULL,
+	mountpoint   TEXT NOT NULL,
+	total        INTEGER NOT NULL,
+	used         INTEGER NOT NULL,
+	used_percent REAL NOT NULL
+);
+CREATE INDEX IF NOT EXISTS mount_samples_ts ON mount_samples (ts);
+CREATE TABLE IF NOT EXISTS annotations (
+	ts    INTEGER NOT NULL,
+	label TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS annotations_ts ON annotations (ts);
+`
+
+// Store persists samples to a SQLite database
+type Store struct {
+	db        *sql.DB
+	retention time.Duration
+
+	mu        sync.Mutex
+	lastPrune time.Time
+}
+
+// OpenStore opens (or creates) the SQLite database at path, keeping samples
+// for the given retention period
+func OpenStore(path string, retention time.Duration) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+
+	s := &Store{
+		db:        db,
+		retention: retention,
+	}
+	if err := s.prune(time.Now()); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Insert writes a sample, pruning expired rows at most once per pruneInterval
+func (s *Store) Insert(sample Sample) error {
+	_, err := s.db.Exec(
+		`INSERT INTO samples (ts, cpu_percent, mem_used_percent, disk_used_percent, net_sent_per_sec, net_recv_per_sec)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		sample.Time.UnixMilli(),
+		sample.CPUPercent,
+		sample.MemUsedPercent,
+		sample.DiskUsedPercent,
+		sample.NetSentPerSec,
+		sample.NetRecvPerSec,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	due := sample.Time.Sub(s.lastPrune) >= pruneInterval
+	s.mu.Unlock()
+	if due {
+		return s.prune(sample.Time)
+	}
+	return nil
+}
+
+// InsertMounts writes the per-mount samples of one tick in a single
+// transaction. Expired rows are pruned together with Insert.
+func (s *Store) InsertMounts(samples []MountSample) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, sample := range samples {
+		_, err := tx.Exec(
+			`INSERT INTO mount_samples (ts, mountpoint, total, used, used_percent) VALUES (?, ?, ?, ?, ?)`,
+			sample.Time.UnixMilli(),
+			sample.Mountpoint,
+			int64(sample.Total),
+			int64(sample.Used),
+			sample.UsedPercent,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// MountRange returns the per-mount samples taken within [from, to], oldest
+// first
+func (s *Store) MountRange(from, to time.Time) ([]MountSample, error) {
+	rows, err := s.db.Query(
+		`SELECT ts, mountpoint, total, used, used_percent
+		FROM mount_samples WHERE ts BETWEEN ? AND ? ORDER BY ts`,
+		from.UnixMilli(),
+		to.UnixMilli(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := []MountSample{}
+	for rows.Next() {
+		var ts, total, used int64
+		var sample MountSample
+		if err := rows.Scan(&ts, &sample.Mountpoint, &total, &used, &sample.UsedPercent); err != nil {
+			return nil, err
+		}
+		sample.Time = time.UnixMilli(ts)
+		sample.Total = uint64(total)
+		sample.Used = uint64(used)
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
+
+// Range returns the samples taken within [from, to], oldest first
+func (s *Store) Range(from, to time.Time) ([]Sample, error) {
+	rows, err := s.db.Query(
+		`SELECT ts, cpu_percent, mem_used_percent, disk_used_percent, net_sent_per_sec, net_recv_per_sec
+		FROM samples WHERE ts BETWEEN ? AND ? ORDER BY ts`,
+		from.UnixMilli(),
+		to.UnixMilli(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := []Sample{}
+	for rows.Next() {
+		var ts int64
+		var sample Sample
+		err := rows.Scan(
+			&ts,
+			&sample.CPUPercent,
+			&sample.MemUsedPercent,
+			&sample.DiskUsedPercent,
+			&sample.NetSentPerSec,
+			&sample.NetRecvPerSec,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sample.Time = time.UnixMilli(ts)
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
+
+// InsertAnnotation writes an annotation. Expired annotations are pruned
+// together with Insert.
+func (s *Store) InsertAnnotation(annotation Annotation) error {
+	_, err := s.db.Exec(
+		`INSERT INTO annotations (ts, label) VALUES (?, ?)`,
+		annotation.Time.UnixMilli(),
+		annotation.Label,
+	)
+	return err
+}
+
+// AnnotationRange returns the annotations within [from, to], oldest first
+func (s *Store) AnnotationRange(from, to time.Time) ([]Annotation, error) {
+	rows, err := s.db.Query(
+		`SELECT ts, label FROM annotations WHERE ts BETWEEN ? AND ? ORDER BY ts`,
+		from.UnixMilli(),
+		to.UnixMilli(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	annotations := []Annotation{}
+	for rows.Next() {
+		var ts int64
+		var annotation Annotation
+		if err := rows.Scan(&ts, &annotation.Label); err != nil {
+			return nil, err
+		}
+		annotation.Time = time.UnixMilli(ts)
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, rows.Err()
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// prune deletes samples, mount samples and annotations older than the
+// retention period
+func (s *Store) prune(now time.Time) error {
+	s.mu.Lock()
+	s.lastPrune = now
+	s.mu.Unlock()
+
+	cutoff := now.Add(-s.retention).UnixMilli()
+	if _, err := s.db.Exec(`DELETE FROM samples WHERE ts < ?`, cutoff); err != nil {
+		return fmt.Errorf("pruning samples: %w", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM mount_samples WHERE ts < ?`, cutoff); err != nil {
+		return fmt.Errorf("pruning mount samples: %w", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM annotations WHERE ts < ?`, cutoff); err != nil {
+		return fmt.Errorf("pruning annotations: %w", err)
+	}
+	return nil
+}
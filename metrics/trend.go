@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"sync"
+
+	"system-monitor/handlers"
+)
+
+// Trend is the direction a value moved over the trend window
+type Trend int
+
+const (
+	// TrendUnknown means there is not enough history to compare yet
+	TrendUnknown Trend = iota
+	TrendSteady
+	TrendUp
+	TrendDown
+)
+
+// trendThreshold is the used-percent change below which a mount counts as
+// steady, so rounding noise does not flip the arrow every tick
+const trendThreshold = 0.1
+
+// MountTrends keeps the last few used-percent readings per mountpoint so the
+// current value can be compared with the one from a fixed number of ticks ago
+type MountTrends struct {
+	mu     sync.RWMutex
+	ticks  int
+	values map[string][]float64
+}
+
+// NewMountTrends creates a tracker comparing each reading to the one taken
+// ticks readings earlier
+func NewMountTrends(ticks int) *MountTrends {
+	return &MountTrends{
+		ticks:  ticks,
+		values: make(map[string][]float64),
+	}
+}
+
+// Add records one reading per mount. Mounts missing from the list are
+// forgotten so a remounted filesystem starts a fresh window.
+func (t *MountTrends) Add(mounts []handlers.MountInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := make(map[string][]float64, len(mounts))
+	for _, m := range mounts {
+		values := append(t.values[m.Mountpoint], m.UsedPercent)
+		if len(values) > t.ticks+1 {
+			values = values[len(values)-t.ticks-1:]
+		}
+		current[m.Mountpoint] = values
+	}
+	t.values = current
+}
+
+// Trends returns the trend of every mount with a full window; mounts still
+// filling their window are reported as TrendUnknown
+func (t *MountTrends) Trends() map[string]Trend {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	trends := make(map[string]Trend, len(t.values))
+	for mountpoint, values := range t.values {
+		if t.ticks < 1 || len(values) <= t.ticks {
+			trends[mountpoint] = TrendUnknown
+			continue
+		}
+
+		delta := values[len(values)-1] - values[0]
+		switch {
+		case delta >= trendThreshold:
+			trends[mountpoint] = TrendUp
+		case delta <= -trendThreshold:
+			trends[mountpoint] = TrendDown
+		default:
+			trends[mountpoint] = TrendSteady
+		}
+	}
+	return trends
+}
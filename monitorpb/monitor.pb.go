@@ -0,0 +1,2550 @@
+// The gRPC feed served on --grpc-port. Messages mirror the JSON snapshot of
+// /api/metrics field for field; optional fields are the ones the JSON
+// omits when they are unknown. Regenerate the Go code with
+// `make generate-proto`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: monitorpb/monitor.proto
+
+package monitorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamMetricsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// alert_only only sends snapshots that change a metric's health status,
+	// like the streams' ?alert-only=
+	AlertOnly     bool `protobuf:"varint,1,opt,name=alert_only,json=alertOnly,proto3" json:"alert_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMetricsRequest) Reset() {
+	*x = StreamMetricsRequest{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMetricsRequest) ProtoMessage() {}
+
+func (x *StreamMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMetricsRequest.ProtoReflect.Descriptor instead.
+func (*StreamMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamMetricsRequest) GetAlertOnly() bool {
+	if x != nil {
+		return x.AlertOnly
+	}
+	return false
+}
+
+type Snapshot struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	System *SystemInfo            `protobuf:"bytes,2,opt,name=system,proto3" json:"system,omitempty"`
+	Disk   *DiskInfo              `protobuf:"bytes,3,opt,name=disk,proto3" json:"disk,omitempty"`
+	Cpu    *CPUInfo               `protobuf:"bytes,4,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// cpu_average is the exponential moving average of overall CPU usage
+	// across ticks, smoothed by --cpu-smoothing
+	CpuAverage float64          `protobuf:"fixed64,5,opt,name=cpu_average,json=cpuAverage,proto3" json:"cpu_average,omitempty"`
+	Network    *NetworkInfo     `protobuf:"bytes,6,opt,name=network,proto3" json:"network,omitempty"`
+	Load       *LoadInfo        `protobuf:"bytes,7,opt,name=load,proto3" json:"load,omitempty"`
+	Numa       []*NUMANodeInfo  `protobuf:"bytes,8,rep,name=numa,proto3" json:"numa,omitempty"`
+	Swap       *SwapInfo        `protobuf:"bytes,9,opt,name=swap,proto3" json:"swap,omitempty"`
+	Pressure   *PressureInfo    `protobuf:"bytes,10,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	Fd         *FDInfo          `protobuf:"bytes,11,opt,name=fd,proto3" json:"fd,omitempty"`
+	Clock      *ClockInfo       `protobuf:"bytes,12,opt,name=clock,proto3" json:"clock,omitempty"`
+	Containers []*ContainerStat `protobuf:"bytes,13,rep,name=containers,proto3" json:"containers,omitempty"`
+	Dirs       []*DirInfo       `protobuf:"bytes,14,rep,name=dirs,proto3" json:"dirs,omitempty"`
+	DiskHealth []*DiskHealth    `protobuf:"bytes,15,rep,name=disk_health,json=diskHealth,proto3" json:"disk_health,omitempty"`
+	Custom     []*CustomMetric  `protobuf:"bytes,16,rep,name=custom,proto3" json:"custom,omitempty"`
+	Processes  *ProcessInfo     `protobuf:"bytes,17,opt,name=processes,proto3" json:"processes,omitempty"`
+	// denied names the collectors refused for lack of privileges
+	Denied []string `protobuf:"bytes,18,rep,name=denied,proto3" json:"denied,omitempty"`
+	// rolling is the peak and average usage over --rolling-window
+	Rolling       *RollingStats `protobuf:"bytes,19,opt,name=rolling,proto3" json:"rolling,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Snapshot) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSystem() *SystemInfo {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDisk() *DiskInfo {
+	if x != nil {
+		return x.Disk
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCpu() *CPUInfo {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCpuAverage() float64 {
+	if x != nil {
+		return x.CpuAverage
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetwork() *NetworkInfo {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+func (x *Snapshot) GetLoad() *LoadInfo {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+func (x *Snapshot) GetNuma() []*NUMANodeInfo {
+	if x != nil {
+		return x.Numa
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSwap() *SwapInfo {
+	if x != nil {
+		return x.Swap
+	}
+	return nil
+}
+
+func (x *Snapshot) GetPressure() *PressureInfo {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
+func (x *Snapshot) GetFd() *FDInfo {
+	if x != nil {
+		return x.Fd
+	}
+	return nil
+}
+
+func (x *Snapshot) GetClock() *ClockInfo {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *Snapshot) GetContainers() []*ContainerStat {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDirs() []*DirInfo {
+	if x != nil {
+		return x.Dirs
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDiskHealth() []*DiskHealth {
+	if x != nil {
+		return x.DiskHealth
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCustom() []*CustomMetric {
+	if x != nil {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *Snapshot) GetProcesses() *ProcessInfo {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDenied() []string {
+	if x != nil {
+		return x.Denied
+	}
+	return nil
+}
+
+func (x *Snapshot) GetRolling() *RollingStats {
+	if x != nil {
+		return x.Rolling
+	}
+	return nil
+}
+
+type RollingStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// seconds is the span the samples cover: the whole window, or less when
+	// partial
+	Seconds       float64 `protobuf:"fixed64,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Partial       bool    `protobuf:"varint,2,opt,name=partial,proto3" json:"partial,omitempty"`
+	Samples       int64   `protobuf:"varint,3,opt,name=samples,proto3" json:"samples,omitempty"`
+	CpuPeak       float64 `protobuf:"fixed64,4,opt,name=cpu_peak,json=cpuPeak,proto3" json:"cpu_peak,omitempty"`
+	CpuAvg        float64 `protobuf:"fixed64,5,opt,name=cpu_avg,json=cpuAvg,proto3" json:"cpu_avg,omitempty"`
+	MemPeak       float64 `protobuf:"fixed64,6,opt,name=mem_peak,json=memPeak,proto3" json:"mem_peak,omitempty"`
+	MemAvg        float64 `protobuf:"fixed64,7,opt,name=mem_avg,json=memAvg,proto3" json:"mem_avg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollingStats) Reset() {
+	*x = RollingStats{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollingStats) ProtoMessage() {}
+
+func (x *RollingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollingStats.ProtoReflect.Descriptor instead.
+func (*RollingStats) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RollingStats) GetSeconds() float64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *RollingStats) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *RollingStats) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *RollingStats) GetCpuPeak() float64 {
+	if x != nil {
+		return x.CpuPeak
+	}
+	return 0
+}
+
+func (x *RollingStats) GetCpuAvg() float64 {
+	if x != nil {
+		return x.CpuAvg
+	}
+	return 0
+}
+
+func (x *RollingStats) GetMemPeak() float64 {
+	if x != nil {
+		return x.MemPeak
+	}
+	return 0
+}
+
+func (x *RollingStats) GetMemAvg() float64 {
+	if x != nil {
+		return x.MemAvg
+	}
+	return 0
+}
+
+type SystemInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Os            string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	Hostname      string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Procs         uint64                 `protobuf:"varint,4,opt,name=procs,proto3" json:"procs,omitempty"`
+	TotalMem      uint64                 `protobuf:"varint,5,opt,name=total_mem,json=totalMem,proto3" json:"total_mem,omitempty"`
+	FreeMem       uint64                 `protobuf:"varint,6,opt,name=free_mem,json=freeMem,proto3" json:"free_mem,omitempty"`
+	UsedPercent   float64                `protobuf:"fixed64,7,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	Unavailable   []string               `protobuf:"bytes,8,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemInfo) Reset() {
+	*x = SystemInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemInfo) ProtoMessage() {}
+
+func (x *SystemInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemInfo.ProtoReflect.Descriptor instead.
+func (*SystemInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SystemInfo) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *SystemInfo) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *SystemInfo) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *SystemInfo) GetProcs() uint64 {
+	if x != nil {
+		return x.Procs
+	}
+	return 0
+}
+
+func (x *SystemInfo) GetTotalMem() uint64 {
+	if x != nil {
+		return x.TotalMem
+	}
+	return 0
+}
+
+func (x *SystemInfo) GetFreeMem() uint64 {
+	if x != nil {
+		return x.FreeMem
+	}
+	return 0
+}
+
+func (x *SystemInfo) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+func (x *SystemInfo) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+type DiskInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Total         uint64                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Used          uint64                 `protobuf:"varint,3,opt,name=used,proto3" json:"used,omitempty"`
+	Free          uint64                 `protobuf:"varint,4,opt,name=free,proto3" json:"free,omitempty"`
+	UsedPercent   float64                `protobuf:"fixed64,5,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	Mounts        []*MountInfo           `protobuf:"bytes,6,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	Io            *DiskIOInfo            `protobuf:"bytes,7,opt,name=io,proto3" json:"io,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskInfo) Reset() {
+	*x = DiskInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskInfo) ProtoMessage() {}
+
+func (x *DiskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskInfo.ProtoReflect.Descriptor instead.
+func (*DiskInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DiskInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DiskInfo) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DiskInfo) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *DiskInfo) GetFree() uint64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+func (x *DiskInfo) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+func (x *DiskInfo) GetMounts() []*MountInfo {
+	if x != nil {
+		return x.Mounts
+	}
+	return nil
+}
+
+func (x *DiskInfo) GetIo() *DiskIOInfo {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+type MountInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mountpoint    string                 `protobuf:"bytes,1,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	Device        string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	Fstype        string                 `protobuf:"bytes,3,opt,name=fstype,proto3" json:"fstype,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,4,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Total         uint64                 `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Used          uint64                 `protobuf:"varint,6,opt,name=used,proto3" json:"used,omitempty"`
+	Free          uint64                 `protobuf:"varint,7,opt,name=free,proto3" json:"free,omitempty"`
+	UsedPercent   float64                `protobuf:"fixed64,8,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MountInfo) Reset() {
+	*x = MountInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MountInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountInfo) ProtoMessage() {}
+
+func (x *MountInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountInfo.ProtoReflect.Descriptor instead.
+func (*MountInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MountInfo) GetMountpoint() string {
+	if x != nil {
+		return x.Mountpoint
+	}
+	return ""
+}
+
+func (x *MountInfo) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *MountInfo) GetFstype() string {
+	if x != nil {
+		return x.Fstype
+	}
+	return ""
+}
+
+func (x *MountInfo) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *MountInfo) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *MountInfo) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *MountInfo) GetFree() uint64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+func (x *MountInfo) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+// DiskIOInfo carries the counters or the rates, as chosen by ?counters=
+// on /api/metrics; the feed always sends both
+type DiskIOInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadBytes     uint64                 `protobuf:"varint,1,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes    uint64                 `protobuf:"varint,2,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadPerSec    float64                `protobuf:"fixed64,3,opt,name=read_per_sec,json=readPerSec,proto3" json:"read_per_sec,omitempty"`
+	WritePerSec   float64                `protobuf:"fixed64,4,opt,name=write_per_sec,json=writePerSec,proto3" json:"write_per_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskIOInfo) Reset() {
+	*x = DiskIOInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskIOInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskIOInfo) ProtoMessage() {}
+
+func (x *DiskIOInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskIOInfo.ProtoReflect.Descriptor instead.
+func (*DiskIOInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DiskIOInfo) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *DiskIOInfo) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *DiskIOInfo) GetReadPerSec() float64 {
+	if x != nil {
+		return x.ReadPerSec
+	}
+	return 0
+}
+
+func (x *DiskIOInfo) GetWritePerSec() float64 {
+	if x != nil {
+		return x.WritePerSec
+	}
+	return 0
+}
+
+type CPUInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelName     string                 `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	Family        string                 `protobuf:"bytes,2,opt,name=family,proto3" json:"family,omitempty"`
+	Mhz           float64                `protobuf:"fixed64,3,opt,name=mhz,proto3" json:"mhz,omitempty"`
+	Percentages   []float64              `protobuf:"fixed64,4,rep,packed,name=percentages,proto3" json:"percentages,omitempty"`
+	Average       *float64               `protobuf:"fixed64,5,opt,name=average,proto3,oneof" json:"average,omitempty"`
+	Steal         *float64               `protobuf:"fixed64,6,opt,name=steal,proto3,oneof" json:"steal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CPUInfo) Reset() {
+	*x = CPUInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CPUInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUInfo) ProtoMessage() {}
+
+func (x *CPUInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUInfo.ProtoReflect.Descriptor instead.
+func (*CPUInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CPUInfo) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *CPUInfo) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *CPUInfo) GetMhz() float64 {
+	if x != nil {
+		return x.Mhz
+	}
+	return 0
+}
+
+func (x *CPUInfo) GetPercentages() []float64 {
+	if x != nil {
+		return x.Percentages
+	}
+	return nil
+}
+
+func (x *CPUInfo) GetAverage() float64 {
+	if x != nil && x.Average != nil {
+		return *x.Average
+	}
+	return 0
+}
+
+func (x *CPUInfo) GetSteal() float64 {
+	if x != nil && x.Steal != nil {
+		return *x.Steal
+	}
+	return 0
+}
+
+type NetworkInfo struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Interfaces []*NetInterfaceInfo    `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	// total sums every listed non-loopback interface
+	Total         *NetInterfaceInfo `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkInfo) Reset() {
+	*x = NetworkInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkInfo) ProtoMessage() {}
+
+func (x *NetworkInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkInfo.ProtoReflect.Descriptor instead.
+func (*NetworkInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NetworkInfo) GetInterfaces() []*NetInterfaceInfo {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *NetworkInfo) GetTotal() *NetInterfaceInfo {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type NetInterfaceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BytesSent     uint64                 `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesRecv     uint64                 `protobuf:"varint,3,opt,name=bytes_recv,json=bytesRecv,proto3" json:"bytes_recv,omitempty"`
+	SentPerSec    float64                `protobuf:"fixed64,4,opt,name=sent_per_sec,json=sentPerSec,proto3" json:"sent_per_sec,omitempty"`
+	RecvPerSec    float64                `protobuf:"fixed64,5,opt,name=recv_per_sec,json=recvPerSec,proto3" json:"recv_per_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetInterfaceInfo) Reset() {
+	*x = NetInterfaceInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetInterfaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetInterfaceInfo) ProtoMessage() {}
+
+func (x *NetInterfaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetInterfaceInfo.ProtoReflect.Descriptor instead.
+func (*NetInterfaceInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NetInterfaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetInterfaceInfo) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *NetInterfaceInfo) GetBytesRecv() uint64 {
+	if x != nil {
+		return x.BytesRecv
+	}
+	return 0
+}
+
+func (x *NetInterfaceInfo) GetSentPerSec() float64 {
+	if x != nil {
+		return x.SentPerSec
+	}
+	return 0
+}
+
+func (x *NetInterfaceInfo) GetRecvPerSec() float64 {
+	if x != nil {
+		return x.RecvPerSec
+	}
+	return 0
+}
+
+type LoadInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Load1         float64                `protobuf:"fixed64,1,opt,name=load1,proto3" json:"load1,omitempty"`
+	Load5         float64                `protobuf:"fixed64,2,opt,name=load5,proto3" json:"load5,omitempty"`
+	Load15        float64                `protobuf:"fixed64,3,opt,name=load15,proto3" json:"load15,omitempty"`
+	Cpus          int32                  `protobuf:"varint,4,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadInfo) Reset() {
+	*x = LoadInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadInfo) ProtoMessage() {}
+
+func (x *LoadInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadInfo.ProtoReflect.Descriptor instead.
+func (*LoadInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LoadInfo) GetLoad1() float64 {
+	if x != nil {
+		return x.Load1
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetLoad5() float64 {
+	if x != nil {
+		return x.Load5
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetLoad15() float64 {
+	if x != nil {
+		return x.Load15
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetCpus() int32 {
+	if x != nil {
+		return x.Cpus
+	}
+	return 0
+}
+
+type NUMANodeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          int32                  `protobuf:"varint,1,opt,name=node,proto3" json:"node,omitempty"`
+	TotalMem      uint64                 `protobuf:"varint,2,opt,name=total_mem,json=totalMem,proto3" json:"total_mem,omitempty"`
+	FreeMem       uint64                 `protobuf:"varint,3,opt,name=free_mem,json=freeMem,proto3" json:"free_mem,omitempty"`
+	UsedMem       uint64                 `protobuf:"varint,4,opt,name=used_mem,json=usedMem,proto3" json:"used_mem,omitempty"`
+	UsedPercent   float64                `protobuf:"fixed64,5,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NUMANodeInfo) Reset() {
+	*x = NUMANodeInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NUMANodeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NUMANodeInfo) ProtoMessage() {}
+
+func (x *NUMANodeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NUMANodeInfo.ProtoReflect.Descriptor instead.
+func (*NUMANodeInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NUMANodeInfo) GetNode() int32 {
+	if x != nil {
+		return x.Node
+	}
+	return 0
+}
+
+func (x *NUMANodeInfo) GetTotalMem() uint64 {
+	if x != nil {
+		return x.TotalMem
+	}
+	return 0
+}
+
+func (x *NUMANodeInfo) GetFreeMem() uint64 {
+	if x != nil {
+		return x.FreeMem
+	}
+	return 0
+}
+
+func (x *NUMANodeInfo) GetUsedMem() uint64 {
+	if x != nil {
+		return x.UsedMem
+	}
+	return 0
+}
+
+func (x *NUMANodeInfo) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+type SwapInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Total          uint64                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Used           uint64                 `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	Free           uint64                 `protobuf:"varint,3,opt,name=free,proto3" json:"free,omitempty"`
+	UsedPercent    float64                `protobuf:"fixed64,4,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	PagesInPerSec  *float64               `protobuf:"fixed64,5,opt,name=pages_in_per_sec,json=pagesInPerSec,proto3,oneof" json:"pages_in_per_sec,omitempty"`
+	PagesOutPerSec *float64               `protobuf:"fixed64,6,opt,name=pages_out_per_sec,json=pagesOutPerSec,proto3,oneof" json:"pages_out_per_sec,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SwapInfo) Reset() {
+	*x = SwapInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapInfo) ProtoMessage() {}
+
+func (x *SwapInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapInfo.ProtoReflect.Descriptor instead.
+func (*SwapInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SwapInfo) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SwapInfo) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *SwapInfo) GetFree() uint64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+func (x *SwapInfo) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+func (x *SwapInfo) GetPagesInPerSec() float64 {
+	if x != nil && x.PagesInPerSec != nil {
+		return *x.PagesInPerSec
+	}
+	return 0
+}
+
+func (x *SwapInfo) GetPagesOutPerSec() float64 {
+	if x != nil && x.PagesOutPerSec != nil {
+		return *x.PagesOutPerSec
+	}
+	return 0
+}
+
+type PressureInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cpu           *ResourcePressure      `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory        *ResourcePressure      `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Io            *ResourcePressure      `protobuf:"bytes,3,opt,name=io,proto3" json:"io,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureInfo) Reset() {
+	*x = PressureInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureInfo) ProtoMessage() {}
+
+func (x *PressureInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureInfo.ProtoReflect.Descriptor instead.
+func (*PressureInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PressureInfo) GetCpu() *ResourcePressure {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *PressureInfo) GetMemory() *ResourcePressure {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *PressureInfo) GetIo() *ResourcePressure {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+type ResourcePressure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Some  *PressureStall         `protobuf:"bytes,1,opt,name=some,proto3" json:"some,omitempty"`
+	// full is unset for CPU on kernels that only report "some"
+	Full          *PressureStall `protobuf:"bytes,2,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourcePressure) Reset() {
+	*x = ResourcePressure{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourcePressure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourcePressure) ProtoMessage() {}
+
+func (x *ResourcePressure) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourcePressure.ProtoReflect.Descriptor instead.
+func (*ResourcePressure) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResourcePressure) GetSome() *PressureStall {
+	if x != nil {
+		return x.Some
+	}
+	return nil
+}
+
+func (x *ResourcePressure) GetFull() *PressureStall {
+	if x != nil {
+		return x.Full
+	}
+	return nil
+}
+
+type PressureStall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Avg10         float64                `protobuf:"fixed64,1,opt,name=avg10,proto3" json:"avg10,omitempty"`
+	Avg60         float64                `protobuf:"fixed64,2,opt,name=avg60,proto3" json:"avg60,omitempty"`
+	Avg300        float64                `protobuf:"fixed64,3,opt,name=avg300,proto3" json:"avg300,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureStall) Reset() {
+	*x = PressureStall{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureStall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureStall) ProtoMessage() {}
+
+func (x *PressureStall) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureStall.ProtoReflect.Descriptor instead.
+func (*PressureStall) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PressureStall) GetAvg10() float64 {
+	if x != nil {
+		return x.Avg10
+	}
+	return 0
+}
+
+func (x *PressureStall) GetAvg60() float64 {
+	if x != nil {
+		return x.Avg60
+	}
+	return 0
+}
+
+func (x *PressureStall) GetAvg300() float64 {
+	if x != nil {
+		return x.Avg300
+	}
+	return 0
+}
+
+type FDInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Open          uint64                 `protobuf:"varint,1,opt,name=open,proto3" json:"open,omitempty"`
+	SoftLimit     uint64                 `protobuf:"varint,2,opt,name=soft_limit,json=softLimit,proto3" json:"soft_limit,omitempty"`
+	HardLimit     uint64                 `protobuf:"varint,3,opt,name=hard_limit,json=hardLimit,proto3" json:"hard_limit,omitempty"`
+	SystemOpen    *uint64                `protobuf:"varint,4,opt,name=system_open,json=systemOpen,proto3,oneof" json:"system_open,omitempty"`
+	SystemMax     *uint64                `protobuf:"varint,5,opt,name=system_max,json=systemMax,proto3,oneof" json:"system_max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FDInfo) Reset() {
+	*x = FDInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FDInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FDInfo) ProtoMessage() {}
+
+func (x *FDInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FDInfo.ProtoReflect.Descriptor instead.
+func (*FDInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FDInfo) GetOpen() uint64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *FDInfo) GetSoftLimit() uint64 {
+	if x != nil {
+		return x.SoftLimit
+	}
+	return 0
+}
+
+func (x *FDInfo) GetHardLimit() uint64 {
+	if x != nil {
+		return x.HardLimit
+	}
+	return 0
+}
+
+func (x *FDInfo) GetSystemOpen() uint64 {
+	if x != nil && x.SystemOpen != nil {
+		return *x.SystemOpen
+	}
+	return 0
+}
+
+func (x *FDInfo) GetSystemMax() uint64 {
+	if x != nil && x.SystemMax != nil {
+		return *x.SystemMax
+	}
+	return 0
+}
+
+type ClockInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Synced        *bool                  `protobuf:"varint,1,opt,name=synced,proto3,oneof" json:"synced,omitempty"`
+	OffsetMs      *float64               `protobuf:"fixed64,2,opt,name=offset_ms,json=offsetMs,proto3,oneof" json:"offset_ms,omitempty"`
+	MaxErrorMs    *float64               `protobuf:"fixed64,3,opt,name=max_error_ms,json=maxErrorMs,proto3,oneof" json:"max_error_ms,omitempty"`
+	Server        string                 `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	Stratum       int32                  `protobuf:"varint,5,opt,name=stratum,proto3" json:"stratum,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClockInfo) Reset() {
+	*x = ClockInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockInfo) ProtoMessage() {}
+
+func (x *ClockInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockInfo.ProtoReflect.Descriptor instead.
+func (*ClockInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClockInfo) GetSynced() bool {
+	if x != nil && x.Synced != nil {
+		return *x.Synced
+	}
+	return false
+}
+
+func (x *ClockInfo) GetOffsetMs() float64 {
+	if x != nil && x.OffsetMs != nil {
+		return *x.OffsetMs
+	}
+	return 0
+}
+
+func (x *ClockInfo) GetMaxErrorMs() float64 {
+	if x != nil && x.MaxErrorMs != nil {
+		return *x.MaxErrorMs
+	}
+	return 0
+}
+
+func (x *ClockInfo) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *ClockInfo) GetStratum() int32 {
+	if x != nil {
+		return x.Stratum
+	}
+	return 0
+}
+
+func (x *ClockInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ContainerStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemUsage      uint64                 `protobuf:"varint,4,opt,name=mem_usage,json=memUsage,proto3" json:"mem_usage,omitempty"`
+	MemLimit      uint64                 `protobuf:"varint,5,opt,name=mem_limit,json=memLimit,proto3" json:"mem_limit,omitempty"`
+	MemPercent    float64                `protobuf:"fixed64,6,opt,name=mem_percent,json=memPercent,proto3" json:"mem_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerStat) Reset() {
+	*x = ContainerStat{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerStat) ProtoMessage() {}
+
+func (x *ContainerStat) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerStat.ProtoReflect.Descriptor instead.
+func (*ContainerStat) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ContainerStat) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ContainerStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerStat) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ContainerStat) GetMemUsage() uint64 {
+	if x != nil {
+		return x.MemUsage
+	}
+	return 0
+}
+
+func (x *ContainerStat) GetMemLimit() uint64 {
+	if x != nil {
+		return x.MemLimit
+	}
+	return 0
+}
+
+func (x *ContainerStat) GetMemPercent() float64 {
+	if x != nil {
+		return x.MemPercent
+	}
+	return 0
+}
+
+type DirInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Bytes         uint64                 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Files         int64                  `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	Skipped       int64                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DirInfo) Reset() {
+	*x = DirInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DirInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirInfo) ProtoMessage() {}
+
+func (x *DirInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirInfo.ProtoReflect.Descriptor instead.
+func (*DirInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DirInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DirInfo) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *DirInfo) GetFiles() int64 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *DirInfo) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *DirInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DiskHealth struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Device             string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Model              string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Serial             string                 `protobuf:"bytes,3,opt,name=serial,proto3" json:"serial,omitempty"`
+	Passed             bool                   `protobuf:"varint,4,opt,name=passed,proto3" json:"passed,omitempty"`
+	Temperature        *int64                 `protobuf:"varint,5,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	PowerOnHours       *int64                 `protobuf:"varint,6,opt,name=power_on_hours,json=powerOnHours,proto3,oneof" json:"power_on_hours,omitempty"`
+	ReallocatedSectors *int64                 `protobuf:"varint,7,opt,name=reallocated_sectors,json=reallocatedSectors,proto3,oneof" json:"reallocated_sectors,omitempty"`
+	PendingSectors     *int64                 `protobuf:"varint,8,opt,name=pending_sectors,json=pendingSectors,proto3,oneof" json:"pending_sectors,omitempty"`
+	MediaErrors        *int64                 `protobuf:"varint,9,opt,name=media_errors,json=mediaErrors,proto3,oneof" json:"media_errors,omitempty"`
+	Error              string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DiskHealth) Reset() {
+	*x = DiskHealth{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskHealth) ProtoMessage() {}
+
+func (x *DiskHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskHealth.ProtoReflect.Descriptor instead.
+func (*DiskHealth) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DiskHealth) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DiskHealth) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *DiskHealth) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *DiskHealth) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *DiskHealth) GetTemperature() int64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *DiskHealth) GetPowerOnHours() int64 {
+	if x != nil && x.PowerOnHours != nil {
+		return *x.PowerOnHours
+	}
+	return 0
+}
+
+func (x *DiskHealth) GetReallocatedSectors() int64 {
+	if x != nil && x.ReallocatedSectors != nil {
+		return *x.ReallocatedSectors
+	}
+	return 0
+}
+
+func (x *DiskHealth) GetPendingSectors() int64 {
+	if x != nil && x.PendingSectors != nil {
+		return *x.PendingSectors
+	}
+	return 0
+}
+
+func (x *DiskHealth) GetMediaErrors() int64 {
+	if x != nil && x.MediaErrors != nil {
+		return *x.MediaErrors
+	}
+	return 0
+}
+
+func (x *DiskHealth) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CustomMetric struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Unit          string                 `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Value         *float64               `protobuf:"fixed64,3,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Stderr        string                 `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomMetric) Reset() {
+	*x = CustomMetric{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomMetric) ProtoMessage() {}
+
+func (x *CustomMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomMetric.ProtoReflect.Descriptor instead.
+func (*CustomMetric) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CustomMetric) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomMetric) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *CustomMetric) GetValue() float64 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+func (x *CustomMetric) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CustomMetric) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+type ProcessInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Processes []*ProcessStat         `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	Groups    []*ProcessGroup        `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	Total     int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Zombies   *int64                 `protobuf:"varint,4,opt,name=zombies,proto3,oneof" json:"zombies,omitempty"`
+	// io is set when the rows carry read and write rates
+	Io            bool `protobuf:"varint,5,opt,name=io,proto3" json:"io,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessInfo) Reset() {
+	*x = ProcessInfo{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessInfo) ProtoMessage() {}
+
+func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessInfo.ProtoReflect.Descriptor instead.
+func (*ProcessInfo) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ProcessInfo) GetProcesses() []*ProcessStat {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *ProcessInfo) GetGroups() []*ProcessGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ProcessInfo) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetZombies() int64 {
+	if x != nil && x.Zombies != nil {
+		return *x.Zombies
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetIo() bool {
+	if x != nil {
+		return x.Io
+	}
+	return false
+}
+
+type ProcessStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ppid          int32                  `protobuf:"varint,2,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,4,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemPercent    float64                `protobuf:"fixed64,5,opt,name=mem_percent,json=memPercent,proto3" json:"mem_percent,omitempty"`
+	Rss           uint64                 `protobuf:"varint,6,opt,name=rss,proto3" json:"rss,omitempty"`
+	Io            *ProcessIO             `protobuf:"bytes,7,opt,name=io,proto3" json:"io,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessStat) Reset() {
+	*x = ProcessStat{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessStat) ProtoMessage() {}
+
+func (x *ProcessStat) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessStat.ProtoReflect.Descriptor instead.
+func (*ProcessStat) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProcessStat) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessStat) GetPpid() int32 {
+	if x != nil {
+		return x.Ppid
+	}
+	return 0
+}
+
+func (x *ProcessStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcessStat) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ProcessStat) GetMemPercent() float64 {
+	if x != nil {
+		return x.MemPercent
+	}
+	return 0
+}
+
+func (x *ProcessStat) GetRss() uint64 {
+	if x != nil {
+		return x.Rss
+	}
+	return 0
+}
+
+func (x *ProcessStat) GetIo() *ProcessIO {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+type ProcessGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentPid     int32                  `protobuf:"varint,3,opt,name=parent_pid,json=parentPid,proto3" json:"parent_pid,omitempty"`
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,5,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemPercent    float64                `protobuf:"fixed64,6,opt,name=mem_percent,json=memPercent,proto3" json:"mem_percent,omitempty"`
+	Rss           uint64                 `protobuf:"varint,7,opt,name=rss,proto3" json:"rss,omitempty"`
+	Io            *ProcessIO             `protobuf:"bytes,8,opt,name=io,proto3" json:"io,omitempty"`
+	Processes     []*ProcessStat         `protobuf:"bytes,9,rep,name=processes,proto3" json:"processes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessGroup) Reset() {
+	*x = ProcessGroup{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessGroup) ProtoMessage() {}
+
+func (x *ProcessGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessGroup.ProtoReflect.Descriptor instead.
+func (*ProcessGroup) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProcessGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProcessGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcessGroup) GetParentPid() int32 {
+	if x != nil {
+		return x.ParentPid
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetMemPercent() float64 {
+	if x != nil {
+		return x.MemPercent
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetRss() uint64 {
+	if x != nil {
+		return x.Rss
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetIo() *ProcessIO {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+func (x *ProcessGroup) GetProcesses() []*ProcessStat {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+type ProcessIO struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadBytes     uint64                 `protobuf:"varint,1,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes    uint64                 `protobuf:"varint,2,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadPerSec    float64                `protobuf:"fixed64,3,opt,name=read_per_sec,json=readPerSec,proto3" json:"read_per_sec,omitempty"`
+	WritePerSec   float64                `protobuf:"fixed64,4,opt,name=write_per_sec,json=writePerSec,proto3" json:"write_per_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessIO) Reset() {
+	*x = ProcessIO{}
+	mi := &file_monitorpb_monitor_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessIO) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessIO) ProtoMessage() {}
+
+func (x *ProcessIO) ProtoReflect() protoreflect.Message {
+	mi := &file_monitorpb_monitor_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessIO.ProtoReflect.Descriptor instead.
+func (*ProcessIO) Descriptor() ([]byte, []int) {
+	return file_monitorpb_monitor_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ProcessIO) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *ProcessIO) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *ProcessIO) GetReadPerSec() float64 {
+	if x != nil {
+		return x.ReadPerSec
+	}
+	return 0
+}
+
+func (x *ProcessIO) GetWritePerSec() float64 {
+	if x != nil {
+		return x.WritePerSec
+	}
+	return 0
+}
+
+var File_monitorpb_monitor_proto protoreflect.FileDescriptor
+
+const file_monitorpb_monitor_proto_rawDesc = "" +
+	"\n" +
+	"\x17monitorpb/monitor.proto\x12\n" +
+	"monitor.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"5\n" +
+	"\x14StreamMetricsRequest\x12\x1d\n" +
+	"\n" +
+	"alert_only\x18\x01 \x01(\bR\talertOnly\"\xea\x06\n" +
+	"\bSnapshot\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12.\n" +
+	"\x06system\x18\x02 \x01(\v2\x16.monitor.v1.SystemInfoR\x06system\x12(\n" +
+	"\x04disk\x18\x03 \x01(\v2\x14.monitor.v1.DiskInfoR\x04disk\x12%\n" +
+	"\x03cpu\x18\x04 \x01(\v2\x13.monitor.v1.CPUInfoR\x03cpu\x12\x1f\n" +
+	"\vcpu_average\x18\x05 \x01(\x01R\n" +
+	"cpuAverage\x121\n" +
+	"\anetwork\x18\x06 \x01(\v2\x17.monitor.v1.NetworkInfoR\anetwork\x12(\n" +
+	"\x04load\x18\a \x01(\v2\x14.monitor.v1.LoadInfoR\x04load\x12,\n" +
+	"\x04numa\x18\b \x03(\v2\x18.monitor.v1.NUMANodeInfoR\x04numa\x12(\n" +
+	"\x04swap\x18\t \x01(\v2\x14.monitor.v1.SwapInfoR\x04swap\x124\n" +
+	"\bpressure\x18\n" +
+	" \x01(\v2\x18.monitor.v1.PressureInfoR\bpressure\x12\"\n" +
+	"\x02fd\x18\v \x01(\v2\x12.monitor.v1.FDInfoR\x02fd\x12+\n" +
+	"\x05clock\x18\f \x01(\v2\x15.monitor.v1.ClockInfoR\x05clock\x129\n" +
+	"\n" +
+	"containers\x18\r \x03(\v2\x19.monitor.v1.ContainerStatR\n" +
+	"containers\x12'\n" +
+	"\x04dirs\x18\x0e \x03(\v2\x13.monitor.v1.DirInfoR\x04dirs\x127\n" +
+	"\vdisk_health\x18\x0f \x03(\v2\x16.monitor.v1.DiskHealthR\n" +
+	"diskHealth\x120\n" +
+	"\x06custom\x18\x10 \x03(\v2\x18.monitor.v1.CustomMetricR\x06custom\x125\n" +
+	"\tprocesses\x18\x11 \x01(\v2\x17.monitor.v1.ProcessInfoR\tprocesses\x12\x16\n" +
+	"\x06denied\x18\x12 \x03(\tR\x06denied\x122\n" +
+	"\arolling\x18\x13 \x01(\v2\x18.monitor.v1.RollingStatsR\arolling\"\xc4\x01\n" +
+	"\fRollingStats\x12\x18\n" +
+	"\aseconds\x18\x01 \x01(\x01R\aseconds\x12\x18\n" +
+	"\apartial\x18\x02 \x01(\bR\apartial\x12\x18\n" +
+	"\asamples\x18\x03 \x01(\x03R\asamples\x12\x19\n" +
+	"\bcpu_peak\x18\x04 \x01(\x01R\acpuPeak\x12\x17\n" +
+	"\acpu_avg\x18\x05 \x01(\x01R\x06cpuAvg\x12\x19\n" +
+	"\bmem_peak\x18\x06 \x01(\x01R\amemPeak\x12\x17\n" +
+	"\amem_avg\x18\a \x01(\x01R\x06memAvg\"\xe7\x01\n" +
+	"\n" +
+	"SystemInfo\x12\x0e\n" +
+	"\x02os\x18\x01 \x01(\tR\x02os\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x12\x14\n" +
+	"\x05procs\x18\x04 \x01(\x04R\x05procs\x12\x1b\n" +
+	"\ttotal_mem\x18\x05 \x01(\x04R\btotalMem\x12\x19\n" +
+	"\bfree_mem\x18\x06 \x01(\x04R\afreeMem\x12!\n" +
+	"\fused_percent\x18\a \x01(\x01R\vusedPercent\x12 \n" +
+	"\vunavailable\x18\b \x03(\tR\vunavailable\"\xd6\x01\n" +
+	"\bDiskInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x04R\x05total\x12\x12\n" +
+	"\x04used\x18\x03 \x01(\x04R\x04used\x12\x12\n" +
+	"\x04free\x18\x04 \x01(\x04R\x04free\x12!\n" +
+	"\fused_percent\x18\x05 \x01(\x01R\vusedPercent\x12-\n" +
+	"\x06mounts\x18\x06 \x03(\v2\x15.monitor.v1.MountInfoR\x06mounts\x12&\n" +
+	"\x02io\x18\a \x01(\v2\x16.monitor.v1.DiskIOInfoR\x02io\"\xd9\x01\n" +
+	"\tMountInfo\x12\x1e\n" +
+	"\n" +
+	"mountpoint\x18\x01 \x01(\tR\n" +
+	"mountpoint\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12\x16\n" +
+	"\x06fstype\x18\x03 \x01(\tR\x06fstype\x12\x1b\n" +
+	"\tread_only\x18\x04 \x01(\bR\breadOnly\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x04R\x05total\x12\x12\n" +
+	"\x04used\x18\x06 \x01(\x04R\x04used\x12\x12\n" +
+	"\x04free\x18\a \x01(\x04R\x04free\x12!\n" +
+	"\fused_percent\x18\b \x01(\x01R\vusedPercent\"\x92\x01\n" +
+	"\n" +
+	"DiskIOInfo\x12\x1d\n" +
+	"\n" +
+	"read_bytes\x18\x01 \x01(\x04R\treadBytes\x12\x1f\n" +
+	"\vwrite_bytes\x18\x02 \x01(\x04R\n" +
+	"writeBytes\x12 \n" +
+	"\fread_per_sec\x18\x03 \x01(\x01R\n" +
+	"readPerSec\x12\"\n" +
+	"\rwrite_per_sec\x18\x04 \x01(\x01R\vwritePerSec\"\xc4\x01\n" +
+	"\aCPUInfo\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12\x16\n" +
+	"\x06family\x18\x02 \x01(\tR\x06family\x12\x10\n" +
+	"\x03mhz\x18\x03 \x01(\x01R\x03mhz\x12 \n" +
+	"\vpercentages\x18\x04 \x03(\x01R\vpercentages\x12\x1d\n" +
+	"\aaverage\x18\x05 \x01(\x01H\x00R\aaverage\x88\x01\x01\x12\x19\n" +
+	"\x05steal\x18\x06 \x01(\x01H\x01R\x05steal\x88\x01\x01B\n" +
+	"\n" +
+	"\b_averageB\b\n" +
+	"\x06_steal\"\x7f\n" +
+	"\vNetworkInfo\x12<\n" +
+	"\n" +
+	"interfaces\x18\x01 \x03(\v2\x1c.monitor.v1.NetInterfaceInfoR\n" +
+	"interfaces\x122\n" +
+	"\x05total\x18\x02 \x01(\v2\x1c.monitor.v1.NetInterfaceInfoR\x05total\"\xa8\x01\n" +
+	"\x10NetInterfaceInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x02 \x01(\x04R\tbytesSent\x12\x1d\n" +
+	"\n" +
+	"bytes_recv\x18\x03 \x01(\x04R\tbytesRecv\x12 \n" +
+	"\fsent_per_sec\x18\x04 \x01(\x01R\n" +
+	"sentPerSec\x12 \n" +
+	"\frecv_per_sec\x18\x05 \x01(\x01R\n" +
+	"recvPerSec\"b\n" +
+	"\bLoadInfo\x12\x14\n" +
+	"\x05load1\x18\x01 \x01(\x01R\x05load1\x12\x14\n" +
+	"\x05load5\x18\x02 \x01(\x01R\x05load5\x12\x16\n" +
+	"\x06load15\x18\x03 \x01(\x01R\x06load15\x12\x12\n" +
+	"\x04cpus\x18\x04 \x01(\x05R\x04cpus\"\x98\x01\n" +
+	"\fNUMANodeInfo\x12\x12\n" +
+	"\x04node\x18\x01 \x01(\x05R\x04node\x12\x1b\n" +
+	"\ttotal_mem\x18\x02 \x01(\x04R\btotalMem\x12\x19\n" +
+	"\bfree_mem\x18\x03 \x01(\x04R\afreeMem\x12\x19\n" +
+	"\bused_mem\x18\x04 \x01(\x04R\ausedMem\x12!\n" +
+	"\fused_percent\x18\x05 \x01(\x01R\vusedPercent\"\xf4\x01\n" +
+	"\bSwapInfo\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x04R\x05total\x12\x12\n" +
+	"\x04used\x18\x02 \x01(\x04R\x04used\x12\x12\n" +
+	"\x04free\x18\x03 \x01(\x04R\x04free\x12!\n" +
+	"\fused_percent\x18\x04 \x01(\x01R\vusedPercent\x12,\n" +
+	"\x10pages_in_per_sec\x18\x05 \x01(\x01H\x00R\rpagesInPerSec\x88\x01\x01\x12.\n" +
+	"\x11pages_out_per_sec\x18\x06 \x01(\x01H\x01R\x0epagesOutPerSec\x88\x01\x01B\x13\n" +
+	"\x11_pages_in_per_secB\x14\n" +
+	"\x12_pages_out_per_sec\"\xa2\x01\n" +
+	"\fPressureInfo\x12.\n" +
+	"\x03cpu\x18\x01 \x01(\v2\x1c.monitor.v1.ResourcePressureR\x03cpu\x124\n" +
+	"\x06memory\x18\x02 \x01(\v2\x1c.monitor.v1.ResourcePressureR\x06memory\x12,\n" +
+	"\x02io\x18\x03 \x01(\v2\x1c.monitor.v1.ResourcePressureR\x02io\"p\n" +
+	"\x10ResourcePressure\x12-\n" +
+	"\x04some\x18\x01 \x01(\v2\x19.monitor.v1.PressureStallR\x04some\x12-\n" +
+	"\x04full\x18\x02 \x01(\v2\x19.monitor.v1.PressureStallR\x04full\"S\n" +
+	"\rPressureStall\x12\x14\n" +
+	"\x05avg10\x18\x01 \x01(\x01R\x05avg10\x12\x14\n" +
+	"\x05avg60\x18\x02 \x01(\x01R\x05avg60\x12\x16\n" +
+	"\x06avg300\x18\x03 \x01(\x01R\x06avg300\"\xc3\x01\n" +
+	"\x06FDInfo\x12\x12\n" +
+	"\x04open\x18\x01 \x01(\x04R\x04open\x12\x1d\n" +
+	"\n" +
+	"soft_limit\x18\x02 \x01(\x04R\tsoftLimit\x12\x1d\n" +
+	"\n" +
+	"hard_limit\x18\x03 \x01(\x04R\thardLimit\x12$\n" +
+	"\vsystem_open\x18\x04 \x01(\x04H\x00R\n" +
+	"systemOpen\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"system_max\x18\x05 \x01(\x04H\x01R\tsystemMax\x88\x01\x01B\x0e\n" +
+	"\f_system_openB\r\n" +
+	"\v_system_max\"\xe3\x01\n" +
+	"\tClockInfo\x12\x1b\n" +
+	"\x06synced\x18\x01 \x01(\bH\x00R\x06synced\x88\x01\x01\x12 \n" +
+	"\toffset_ms\x18\x02 \x01(\x01H\x01R\boffsetMs\x88\x01\x01\x12%\n" +
+	"\fmax_error_ms\x18\x03 \x01(\x01H\x02R\n" +
+	"maxErrorMs\x88\x01\x01\x12\x16\n" +
+	"\x06server\x18\x04 \x01(\tR\x06server\x12\x18\n" +
+	"\astratum\x18\x05 \x01(\x05R\astratum\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05errorB\t\n" +
+	"\a_syncedB\f\n" +
+	"\n" +
+	"_offset_msB\x0f\n" +
+	"\r_max_error_ms\"\xaf\x01\n" +
+	"\rContainerStat\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcpu_percent\x18\x03 \x01(\x01R\n" +
+	"cpuPercent\x12\x1b\n" +
+	"\tmem_usage\x18\x04 \x01(\x04R\bmemUsage\x12\x1b\n" +
+	"\tmem_limit\x18\x05 \x01(\x04R\bmemLimit\x12\x1f\n" +
+	"\vmem_percent\x18\x06 \x01(\x01R\n" +
+	"memPercent\"y\n" +
+	"\aDirInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x04R\x05bytes\x12\x14\n" +
+	"\x05files\x18\x03 \x01(\x03R\x05files\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x03R\askipped\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xbe\x03\n" +
+	"\n" +
+	"DiskHealth\x12\x16\n" +
+	"\x06device\x18\x01 \x01(\tR\x06device\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x16\n" +
+	"\x06serial\x18\x03 \x01(\tR\x06serial\x12\x16\n" +
+	"\x06passed\x18\x04 \x01(\bR\x06passed\x12%\n" +
+	"\vtemperature\x18\x05 \x01(\x03H\x00R\vtemperature\x88\x01\x01\x12)\n" +
+	"\x0epower_on_hours\x18\x06 \x01(\x03H\x01R\fpowerOnHours\x88\x01\x01\x124\n" +
+	"\x13reallocated_sectors\x18\a \x01(\x03H\x02R\x12reallocatedSectors\x88\x01\x01\x12,\n" +
+	"\x0fpending_sectors\x18\b \x01(\x03H\x03R\x0ependingSectors\x88\x01\x01\x12&\n" +
+	"\fmedia_errors\x18\t \x01(\x03H\x04R\vmediaErrors\x88\x01\x01\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05errorB\x0e\n" +
+	"\f_temperatureB\x11\n" +
+	"\x0f_power_on_hoursB\x16\n" +
+	"\x14_reallocated_sectorsB\x12\n" +
+	"\x10_pending_sectorsB\x0f\n" +
+	"\r_media_errors\"\x89\x01\n" +
+	"\fCustomMetric\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\x12\x19\n" +
+	"\x05value\x18\x03 \x01(\x01H\x00R\x05value\x88\x01\x01\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x16\n" +
+	"\x06stderr\x18\x05 \x01(\tR\x06stderrB\b\n" +
+	"\x06_value\"\xc7\x01\n" +
+	"\vProcessInfo\x125\n" +
+	"\tprocesses\x18\x01 \x03(\v2\x17.monitor.v1.ProcessStatR\tprocesses\x120\n" +
+	"\x06groups\x18\x02 \x03(\v2\x18.monitor.v1.ProcessGroupR\x06groups\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x1d\n" +
+	"\azombies\x18\x04 \x01(\x03H\x00R\azombies\x88\x01\x01\x12\x0e\n" +
+	"\x02io\x18\x05 \x01(\bR\x02ioB\n" +
+	"\n" +
+	"\b_zombies\"\xc2\x01\n" +
+	"\vProcessStat\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04ppid\x18\x02 \x01(\x05R\x04ppid\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcpu_percent\x18\x04 \x01(\x01R\n" +
+	"cpuPercent\x12\x1f\n" +
+	"\vmem_percent\x18\x05 \x01(\x01R\n" +
+	"memPercent\x12\x10\n" +
+	"\x03rss\x18\x06 \x01(\x04R\x03rss\x12%\n" +
+	"\x02io\x18\a \x01(\v2\x15.monitor.v1.ProcessIOR\x02io\"\x9b\x02\n" +
+	"\fProcessGroup\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"parent_pid\x18\x03 \x01(\x05R\tparentPid\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x1f\n" +
+	"\vcpu_percent\x18\x05 \x01(\x01R\n" +
+	"cpuPercent\x12\x1f\n" +
+	"\vmem_percent\x18\x06 \x01(\x01R\n" +
+	"memPercent\x12\x10\n" +
+	"\x03rss\x18\a \x01(\x04R\x03rss\x12%\n" +
+	"\x02io\x18\b \x01(\v2\x15.monitor.v1.ProcessIOR\x02io\x125\n" +
+	"\tprocesses\x18\t \x03(\v2\x17.monitor.v1.ProcessStatR\tprocesses\"\x91\x01\n" +
+	"\tProcessIO\x12\x1d\n" +
+	"\n" +
+	"read_bytes\x18\x01 \x01(\x04R\treadBytes\x12\x1f\n" +
+	"\vwrite_bytes\x18\x02 \x01(\x04R\n" +
+	"writeBytes\x12 \n" +
+	"\fread_per_sec\x18\x03 \x01(\x01R\n" +
+	"readPerSec\x12\"\n" +
+	"\rwrite_per_sec\x18\x04 \x01(\x01R\vwritePerSec2T\n" +
+	"\aMonitor\x12I\n" +
+	"\rStreamMetrics\x12 .monitor.v1.StreamMetricsRequest\x1a\x14.monitor.v1.Snapshot0\x01B\x1aZ\x18system-monitor/monitorpbb\x06proto3"
+
+var (
+	file_monitorpb_monitor_proto_rawDescOnce sync.Once
+	file_monitorpb_monitor_proto_rawDescData []byte
+)
+
+func file_monitorpb_monitor_proto_rawDescGZIP() []byte {
+	file_monitorpb_monitor_proto_rawDescOnce.Do(func() {
+		file_monitorpb_monitor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_monitorpb_monitor_proto_rawDesc), len(file_monitorpb_monitor_proto_rawDesc)))
+	})
+	return file_monitorpb_monitor_proto_rawDescData
+}
+
+var file_monitorpb_monitor_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_monitorpb_monitor_proto_goTypes = []any{
+	(*StreamMetricsRequest)(nil),  // 0: monitor.v1.StreamMetricsRequest
+	(*Snapshot)(nil),              // 1: monitor.v1.Snapshot
+	(*RollingStats)(nil),          // 2: monitor.v1.RollingStats
+	(*SystemInfo)(nil),            // 3: monitor.v1.SystemInfo
+	(*DiskInfo)(nil),              // 4: monitor.v1.DiskInfo
+	(*MountInfo)(nil),             // 5: monitor.v1.MountInfo
+	(*DiskIOInfo)(nil),            // 6: monitor.v1.DiskIOInfo
+	(*CPUInfo)(nil),               // 7: monitor.v1.CPUInfo
+	(*NetworkInfo)(nil),           // 8: monitor.v1.NetworkInfo
+	(*NetInterfaceInfo)(nil),      // 9: monitor.v1.NetInterfaceInfo
+	(*LoadInfo)(nil),              // 10: monitor.v1.LoadInfo
+	(*NUMANodeInfo)(nil),          // 11: monitor.v1.NUMANodeInfo
+	(*SwapInfo)(nil),              // 12: monitor.v1.SwapInfo
+	(*PressureInfo)(nil),          // 13: monitor.v1.PressureInfo
+	(*ResourcePressure)(nil),      // 14: monitor.v1.ResourcePressure
+	(*PressureStall)(nil),         // 15: monitor.v1.PressureStall
+	(*FDInfo)(nil),                // 16: monitor.v1.FDInfo
+	(*ClockInfo)(nil),             // 17: monitor.v1.ClockInfo
+	(*ContainerStat)(nil),         // 18: monitor.v1.ContainerStat
+	(*DirInfo)(nil),               // 19: monitor.v1.DirInfo
+	(*DiskHealth)(nil),            // 20: monitor.v1.DiskHealth
+	(*CustomMetric)(nil),          // 21: monitor.v1.CustomMetric
+	(*ProcessInfo)(nil),           // 22: monitor.v1.ProcessInfo
+	(*ProcessStat)(nil),           // 23: monitor.v1.ProcessStat
+	(*ProcessGroup)(nil),          // 24: monitor.v1.ProcessGroup
+	(*ProcessIO)(nil),             // 25: monitor.v1.ProcessIO
+	(*timestamppb.Timestamp)(nil), // 26: google.protobuf.Timestamp
+}
+var file_monitorpb_monitor_proto_depIdxs = []int32{
+	26, // 0: monitor.v1.Snapshot.time:type_name -> google.protobuf.Timestamp
+	3,  // 1: monitor.v1.Snapshot.system:type_name -> monitor.v1.SystemInfo
+	4,  // 2: monitor.v1.Snapshot.disk:type_name -> monitor.v1.DiskInfo
+	7,  // 3: monitor.v1.Snapshot.cpu:type_name -> monitor.v1.CPUInfo
+	8,  // 4: monitor.v1.Snapshot.network:type_name -> monitor.v1.NetworkInfo
+	10, // 5: monitor.v1.Snapshot.load:type_name -> monitor.v1.LoadInfo
+	11, // 6: monitor.v1.Snapshot.numa:type_name -> monitor.v1.NUMANodeInfo
+	12, // 7: monitor.v1.Snapshot.swap:type_name -> monitor.v1.SwapInfo
+	13, // 8: monitor.v1.Snapshot.pressure:type_name -> monitor.v1.PressureInfo
+	16, // 9: monitor.v1.Snapshot.fd:type_name -> monitor.v1.FDInfo
+	17, // 10: monitor.v1.Snapshot.clock:type_name -> monitor.v1.ClockInfo
+	18, // 11: monitor.v1.Snapshot.containers:type_name -> monitor.v1.ContainerStat
+	19, // 12: monitor.v1.Snapshot.dirs:type_name -> monitor.v1.DirInfo
+	20, // 13: monitor.v1.Snapshot.disk_health:type_name -> monitor.v1.DiskHealth
+	21, // 14: monitor.v1.Snapshot.custom:type_name -> monitor.v1.CustomMetric
+	22, // 15: monitor.v1.Snapshot.processes:type_name -> monitor.v1.ProcessInfo
+	2,  // 16: monitor.v1.Snapshot.rolling:type_name -> monitor.v1.RollingStats
+	5,  // 17: monitor.v1.DiskInfo.mounts:type_name -> monitor.v1.MountInfo
+	6,  // 18: monitor.v1.DiskInfo.io:type_name -> monitor.v1.DiskIOInfo
+	9,  // 19: monitor.v1.NetworkInfo.interfaces:type_name -> monitor.v1.NetInterfaceInfo
+	9,  // 20: monitor.v1.NetworkInfo.total:type_name -> monitor.v1.NetInterfaceInfo
+	14, // 21: monitor.v1.PressureInfo.cpu:type_name -> monitor.v1.ResourcePressure
+	14, // 22: monitor.v1.PressureInfo.memory:type_name -> monitor.v1.ResourcePressure
+	14, // 23: monitor.v1.PressureInfo.io:type_name -> monitor.v1.ResourcePressure
+	15, // 24: monitor.v1.ResourcePressure.some:type_name -> monitor.v1.PressureStall
+	15, // 25: monitor.v1.ResourcePressure.full:type_name -> monitor.v1.PressureStall
+	23, // 26: monitor.v1.ProcessInfo.processes:type_name -> monitor.v1.ProcessStat
+	24, // 27: monitor.v1.ProcessInfo.groups:type_name -> monitor.v1.ProcessGroup
+	25, // 28: monitor.v1.ProcessStat.io:type_name -> monitor.v1.ProcessIO
+	25, // 29: monitor.v1.ProcessGroup.io:type_name -> monitor.v1.ProcessIO
+	23, // 30: monitor.v1.ProcessGroup.processes:type_name -> monitor.v1.ProcessStat
+	0,  // 31: monitor.v1.Monitor.StreamMetrics:input_type -> monitor.v1.StreamMetricsRequest
+	1,  // 32: monitor.v1.Monitor.StreamMetrics:output_type -> monitor.v1.Snapshot
+	32, // [32:33] is the sub-list for method output_type
+	31, // [31:32] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
+}
+
+func init() { file_monitorpb_monitor_proto_init() }
+func file_monitorpb_monitor_proto_init() {
+	if File_monitorpb_monitor_proto != nil {
+		return
+	}
+	file_monitorpb_monitor_proto_msgTypes[7].OneofWrappers = []any{}
+	file_monitorpb_monitor_proto_msgTypes[12].OneofWrappers = []any{}
+	file_monitorpb_monitor_proto_msgTypes[16].OneofWrappers = []any{}
+	file_monitorpb_monitor_proto_msgTypes[17].OneofWrappers = []any{}
+	file_monitorpb_monitor_proto_msgTypes[20].OneofWrappers = []any{}
+	file_monitorpb_monitor_proto_msgTypes[21].OneofWrappers = []any{}
+	file_monitorpb_monitor_proto_msgTypes[22].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_monitorpb_monitor_proto_rawDesc), len(file_monitorpb_monitor_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_monitorpb_monitor_proto_goTypes,
+		DependencyIndexes: file_monitorpb_monitor_proto_depIdxs,
+		MessageInfos:      file_monitorpb_monitor_proto_msgTypes,
+	}.Build()
+	File_monitorpb_monitor_proto = out.File
+	file_monitorpb_monitor_proto_goTypes = nil
+	file_monitorpb_monitor_proto_depIdxs = nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sync"
+
+	"system-monitor/console"
+	"system-monitor/handlers"
+)
+
+// mountWatch notices filesystems mounted or unmounted between disk
+// collections, such as USB drives and network shares, and logs them. The
+// mounts are listed afresh every collection, so the panel already follows
+// them; this only reconciles the list against the previous one.
+type mountWatch struct {
+	mu     sync.Mutex
+	known  map[string]handlers.MountInfo
+	primed bool
+}
+
+// reconcile logs the mounts that appeared or disappeared since the previous
+// call. The first call only records the mounts present at startup.
+func (w *mountWatch) reconcile(mounts []handlers.MountInfo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current := make(map[string]handlers.MountInfo, len(mounts))
+	for _, m := range mounts {
+		current[m.Mountpoint] = m
+		if _, ok := w.known[m.Mountpoint]; !ok && w.primed {
+			console.Printf(console.Mount, "Mounted %s (%s, %s)", m.Mountpoint, m.Device, m.Fstype)
+		}
+	}
+	for mountpoint, m := range w.known {
+		if _, ok := current[mountpoint]; !ok {
+			console.Printf(console.Mount, "Unmounted %s (%s)", mountpoint, m.Device)
+		}
+	}
+	w.known = current
+	w.primed = true
+}
+
+// watchMounts wraps a disk collection to reconcile its mounts
+func (w *mountWatch) watchMounts(collect func() (*handlers.DiskInfo, error)) func() (*handlers.DiskInfo, error) {
+	return func() (*handlers.DiskInfo, error) {
+		info, err := collect()
+		if err == nil {
+			w.reconcile(listedMounts(info))
+		}
+		return info, err
+	}
+}
+
+// listedMounts returns the mounts the OS lists, so one whose usage read
+// fails for a tick isn't taken for unmounted
+func listedMounts(info *handlers.DiskInfo) []handlers.MountInfo {
+	if info.Listed != nil {
+		return info.Listed
+	}
+	return info.Mounts
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"system-monitor/handlers"
+)
+
+// TestMountWatch checks the mounts the watcher reconciles against, which
+// decide what it logs as mounted and unmounted on the next collection
+func TestMountWatch(t *testing.T) {
+	root := handlers.MountInfo{Mountpoint: "/", Device: "/dev/sda1", Fstype: "ext4", Total: 100}
+	data := handlers.MountInfo{Mountpoint: "/data", Device: "/dev/sdb1", Fstype: "xfs", Total: 200}
+	usb := handlers.MountInfo{Mountpoint: "/media/usb", Device: "/dev/sdc1", Fstype: "vfat", Total: 16}
+	unread := func(m handlers.MountInfo) handlers.MountInfo {
+		return handlers.MountInfo{Mountpoint: m.Mountpoint, Device: m.Device, Fstype: m.Fstype}
+	}
+
+	ticks := []struct {
+		name   string
+		mounts []handlers.MountInfo
+		listed []handlers.MountInfo
+		want   []string
+	}{
+		{"startup", []handlers.MountInfo{root, data}, []handlers.MountInfo{root, data}, []string{"/", "/data"}},
+		{"usage read fails", []handlers.MountInfo{root}, []handlers.MountInfo{root, unread(data)}, []string{"/", "/data"}},
+		{"usage read recovers", []handlers.MountInfo{root, data}, []handlers.MountInfo{root, data}, []string{"/", "/data"}},
+		{"unmounted", []handlers.MountInfo{root}, []handlers.MountInfo{root}, []string{"/"}},
+		{"mounted unreadable", []handlers.MountInfo{root}, []handlers.MountInfo{root, unread(usb)}, []string{"/", "/media/usb"}},
+		{"source without a listing", []handlers.MountInfo{root, usb}, nil, []string{"/", "/media/usb"}},
+	}
+
+	w := &mountWatch{}
+	for _, tick := range ticks {
+		collect := w.watchMounts(func() (*handlers.DiskInfo, error) {
+			return &handlers.DiskInfo{Mounts: tick.mounts, Listed: tick.listed}, nil
+		})
+		if _, err := collect(); err != nil {
+			t.Fatal(err)
+		}
+
+		var known []string
+		for mountpoint := range w.known {
+			known = append(known, mountpoint)
+		}
+		slices.Sort(known)
+		if !slices.Equal(known, tick.want) {
+			t.Errorf("%s: known mounts = %q, want %q", tick.name, known, tick.want)
+		}
+	}
+}
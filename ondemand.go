@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"system-monitor/console"
+	"system-monitor/metrics"
+	"system-monitor/templates"
+)
+
+// onDemandFields copies the field each collector fills from one snapshot to
+// another, so a {"get":...} request in JSON format is answered with just
+// that metric
+var onDemandFields = map[string]func(dst, src *metrics.Snapshot){
+	collectorSystem: func(dst, src *metrics.Snapshot) { dst.System = src.System },
+	collectorCPU: func(dst, src *metrics.Snapshot) {
+		// The CPU panel also shows the moving average and rolling summary
+		dst.CPU, dst.CPUAverage, dst.Rolling = src.CPU, src.CPUAverage, src.Rolling
+	},
+	collectorDisk:      func(dst, src *metrics.Snapshot) { dst.Disk = src.Disk },
+	collectorNetwork:   func(dst, src *metrics.Snapshot) { dst.Network = src.Network },
+	collectorProcesses: func(dst, src *metrics.Snapshot) { dst.Processes = src.Processes },
+	collectorLoad:      func(dst, src *metrics.Snapshot) { dst.Load = src.Load },
+	collectorNUMA:      func(dst, src *metrics.Snapshot) { dst.NUMA = src.NUMA },
+	collectorSwap:      func(dst, src *metrics.Snapshot) { dst.Swap = src.Swap },
+	collectorPressure:  func(dst, src *metrics.Snapshot) { dst.Pressure = src.Pressure },
+	collectorFD:        func(dst, src *metrics.Snapshot) { dst.FD = src.FD },
+	collectorClock:     func(dst, src *metrics.Snapshot) { dst.Clock = src.Clock },
+	collectorDocker:    func(dst, src *metrics.Snapshot) { dst.Containers = src.Containers },
+	collectorDirs:      func(dst, src *metrics.Snapshot) { dst.Dirs = src.Dirs },
+	collectorSmart:     func(dst, src *metrics.Snapshot) { dst.DiskHealth = src.DiskHealth },
+	collectorCustom:    func(dst, src *metrics.Snapshot) { dst.Custom = src.Custom },
+}
+
+// named returns the enabled collectors behind a --panels name: one, or
+// every --custom-metric for "custom"
+func (c *collectors) named(name string) []runner {
+	var runners []runner
+	for _, r := range c.all() {
+		collector := r.collectorName()
+		if collector == name || (name == collectorCustom && strings.HasPrefix(collector, collectorCustom+":")) {
+			runners = append(runners, r)
+		}
+	}
+	return runners
+}
+
+// sendOnDemand answers a {"get":"<collector>"} message: it runs just that
+// collector and sends the subscriber its panel once, outside the stream,
+// for lazily loaded tabs. HTML clients get the panel's fragment and JSON
+// clients a snapshot holding only that metric; the compact line has no
+// panels to send.
+func (s *Server) sendOnDemand(subscriber *Subscriber, name string) {
+	copyField, known := onDemandFields[name]
+	runners := s.collectors.named(name)
+	if !known || len(runners) == 0 {
+		console.Warnf("Websocket requested unknown or disabled panel %q", name)
+		return
+	}
+	if subscriber.format == formatCompact {
+		console.Warnf("Websocket requested panel %q in compact format, which has none", name)
+		return
+	}
+
+	// A replay collects nothing; its latest frame is answered from instead
+	var snapshot *metrics.Snapshot
+	if s.replay != nil {
+		snapshot = s.getLatest()
+	} else {
+		for _, r := range runners {
+			r.refresh()
+		}
+		// The moving average and history are left to the publisher's ticks,
+		// so a client sending gets in a loop cannot skew the figures everyone
+		// is shown
+		var err error
+		if snapshot, err = s.latestSnapshot(); err != nil {
+			console.Errorf("Error collecting %s on demand: %v", name, err)
+			return
+		}
+		if window := s.getConfig().RollingWindow; window > 0 {
+			snapshot.Rolling = s.history.Rolling(window, snapshot.Time)
+		}
+	}
+	if snapshot == nil {
+		return
+	}
+	presented := s.presentable(snapshot)
+
+	if subscriber.format == formatJSON {
+		partial := &metrics.Snapshot{Time: presented.Time}
+		copyField(partial, presented)
+		data, err := json.Marshal(partial.Rounded(s.getConfig().JSONPrecision))
+		if err != nil {
+			console.Errorf("Error encoding %s on demand: %v", name, err)
+			return
+		}
+		subscriber.send(streamMessage{data: data})
+		return
+	}
+
+	panels := s.framePanels(presented)
+	if presented.Processes != nil {
+		panels = append(panels, processPanel(presented.Processes))
+	}
+	for _, p := range panels {
+		if p.id == collectorPanelIDs[name] {
+			frame := s.assembleFrame(subscriber.ctx, presented, []templates.FramePanel{framePanel(subscriber.ctx, p)})
+			subscriber.send(streamMessage{data: frame})
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+)
+
+// busySource is fakeSource with a settable CPU usage
+type busySource struct {
+	fakeSource
+	percent atomic.Uint64
+}
+
+func (b *busySource) GetCPUInfo() (*handlers.CPUInfo, error) {
+	info, err := b.fakeSource.GetCPUInfo()
+	info.Percentages = []float64{float64(b.percent.Load())}
+	return info, err
+}
+
+func TestOnDemandLeavesCPUAverage(t *testing.T) {
+	source := &busySource{}
+	source.percent.Store(20)
+	cfg, err := loadTestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newServer(cfg, nil, slog.New(slog.DiscardHandler), source)
+	t.Cleanup(s.cancel)
+	s.collectors.refresh()
+	if _, err := s.collectSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if avg := s.cpuAverage.Value(); avg != 20 {
+		t.Fatalf("seeded average = %v, want 20", avg)
+	}
+
+	source.percent.Store(90)
+	subscriber := newTestSubscriber(20)
+	subscriber.ctx = s.ctx
+	subscriber.format = formatJSON
+	for range 10 {
+		s.sendOnDemand(subscriber, collectorCPU)
+		var snapshot metrics.Snapshot
+		if err := json.Unmarshal((<-subscriber.msgs).data, &snapshot); err != nil {
+			t.Fatal(err)
+		}
+		if snapshot.CPU == nil || snapshot.CPU.OverallUsage() != 90 {
+			t.Fatalf("on-demand CPU = %+v, want the refreshed 90%%", snapshot.CPU)
+		}
+		if snapshot.CPUAverage != 20 {
+			t.Errorf("on-demand cpuAverage = %v, want the published 20", snapshot.CPUAverage)
+		}
+	}
+	if avg := s.cpuAverage.Value(); avg != 20 {
+		t.Errorf("average after on-demand gets = %v, want 20", avg)
+	}
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// apiOperation describes one endpoint for the OpenAPI document. The zero
+// method is GET and the zero status 200; a nil request means no body.
+type apiOperation struct {
+	method     string
+	path       string
+	summary    string
+	parameters []map[string]any
+	request    reflect.Type
+	status     int
+	response   reflect.Type
+	// alternatives are the other responses a query parameter can select,
+	// documented with response as a oneOf
+	alternatives []reflect.Type
+	// contentType is the success response's media type when it isn't JSON;
+	// its body is then described as a string
+	contentType string
+	description string
+}
+
+// apiOperations lists the documented endpoints: every /api route but the
+// document itself, plus the JSON ones outside it. The dashboard, stream and
+// telemetry routes are left out. Schemas are reflected from these types, so
+// the document follows the structs.
+var apiOperations = []apiOperation{
+	{
+		path:    "/api/metrics",
+		summary: "Latest collected metrics",
+		parameters: []map[string]any{
+			queryParam("since", "Token from a previous response's X-Metrics-Token header; returns a MetricsDelta of the fields changed since then"),
+			queryParam("cpu", "CPU usage representation: 'cores' for the per-core percentages, 'avg' for their average alone (percentages is null), or 'both' (default). Ignored with 'since'"),
+			queryParam("counters", "Network and disk I/O representation: 'total' for the counters since boot, 'rate' for the current rates, or 'both' (default); the other half is left out. Ignored with 'since'"),
+		},
+		response:     reflect.TypeOf(metrics.Snapshot{}),
+		alternatives: []reflect.Type{reflect.TypeOf(MetricsDelta{})},
+		description:  "The most recent snapshot of every collector, or a MetricsDelta with 'since'",
+	},
+	{
+		path:    "/api/history",
+		summary: "Headline metrics over a time range",
+		parameters: []map[string]any{
+			queryParam("from", "Start of the range as RFC 3339 or unix seconds (default: one hour before 'to')"),
+			queryParam("to", "End of the range as RFC 3339 or unix seconds (default: now)"),
+			queryParam("resolution", "Average samples into buckets of this duration, e.g. 1m"),
+			queryParam("annotations", "true to return a HistoryResponse with the annotations in the range alongside the samples"),
+		},
+		response:     reflect.TypeOf([]metrics.Sample{}),
+		alternatives: []reflect.Type{reflect.TypeOf(HistoryResponse{})},
+		description:  "Samples ordered oldest first, or a HistoryResponse with 'annotations'",
+	},
+	{
+		path:    "/api/annotations",
+		summary: "Timeline annotations",
+		parameters: []map[string]any{
+			queryParam("from", "Start of the range as RFC 3339 or unix seconds (default: one hour before 'to')"),
+			queryParam("to", "End of the range as RFC 3339 or unix seconds (default: now)"),
+		},
+		response:    reflect.TypeOf([]metrics.Annotation{}),
+		description: "Events such as deployments ordered oldest first",
+	},
+	{
+		method:      fiber.MethodPost,
+		path:        "/api/annotations",
+		summary:     "Add a timeline annotation",
+		request:     reflect.TypeOf(AnnotationRequest{}),
+		status:      fiber.StatusCreated,
+		response:    reflect.TypeOf(metrics.Annotation{}),
+		description: "The annotation recorded. Requires basic auth with the --admin-password credentials",
+	},
+	{
+		path:    "/api/disk-report",
+		summary: "Per-mount disk usage report",
+		parameters: []map[string]any{
+			queryParam("from", "Start of the range as RFC 3339 or unix seconds (default: all retained history)"),
+			queryParam("to", "End of the range as RFC 3339 or unix seconds (default: now)"),
+			queryParam("format", "json (default) or csv for a spreadsheet download"),
+		},
+		response:    reflect.TypeOf(metrics.DiskReport{}),
+		description: "Used percent range, growth and projected days until full for each mountpoint",
+	},
+	{
+		path:        "/api/processes",
+		summary:     "Busiest processes",
+		response:    reflect.TypeOf(handlers.ProcessInfo{}),
+		description: "Processes in the --process-sort order: by CPU then memory usage, or by combined read and write rate with io",
+	},
+	{
+		path:        "/api/subscribers",
+		summary:     "Connected stream clients",
+		response:    reflect.TypeOf(SubscriberList{}),
+		description: "Requires basic auth with the --admin-password credentials",
+	},
+	{
+		method:      fiber.MethodPost,
+		path:        "/api/refresh",
+		summary:     "Collect and broadcast now",
+		status:      fiber.StatusAccepted,
+		response:    reflect.TypeOf(RefreshResponse{}),
+		description: "Sequence number and /api/metrics?since= token of the snapshot published out of band with the ticker. Requires basic auth with the --admin-password credentials",
+	},
+	{
+		method:  fiber.MethodPost,
+		path:    "/api/share",
+		summary: "Sign a read-only share link",
+		parameters: []map[string]any{
+			queryParam("ttl", "How long the link stays valid, e.g. 24h (default: --share-ttl)"),
+		},
+		response:    reflect.TypeOf(ShareResponse{}),
+		description: "A dashboard link that permits reads until it expires. Requires --protect-dashboard, --share-secret and basic auth with the --admin-password credentials",
+	},
+	{
+		path:        "/api/logs",
+		summary:     "Live log stream",
+		contentType: "text/event-stream",
+		description: "Server-Sent Events carrying the monitor's log lines, starting with the most recent ones. Requires basic auth with the --admin-password credentials",
+	},
+	{
+		path:        "/api/quiet",
+		summary:     "Maintenance window",
+		response:    reflect.TypeOf(QuietStatus{}),
+		description: "Whether threshold alerts are suppressed, and until when. Requires basic auth with the --admin-password credentials",
+	},
+	{
+		method:  fiber.MethodPost,
+		path:    "/api/quiet",
+		summary: "Open a maintenance window",
+		parameters: []map[string]any{
+			queryParam("duration", "How long to suppress threshold alerts, e.g. 30m (required)"),
+			queryParam("reason", "Shown in the dashboard banner"),
+		},
+		response:    reflect.TypeOf(QuietStatus{}),
+		description: "The window opened, replacing any active one. Requires basic auth with the --admin-password credentials",
+	},
+	{
+		method:      fiber.MethodDelete,
+		path:        "/api/quiet",
+		summary:     "End the maintenance window",
+		response:    reflect.TypeOf(QuietStatus{}),
+		description: "Alerts resume straight away. Requires basic auth with the --admin-password credentials",
+	},
+	{
+		path:        "/readyz",
+		summary:     "Readiness",
+		response:    reflect.TypeOf(ReadyStatus{}),
+		description: "Status 'ready' once the collectors have warmed up; until then 503 with status 'starting'",
+	},
+	{
+		path:        "/grafana-dashboard.json",
+		summary:     "Grafana dashboard",
+		response:    reflect.TypeOf(grafanaDashboard{}),
+		description: "Dashboard definition to import into Grafana, charting the --push-gateway gauges and the /metrics telemetry from a Prometheus data source",
+	},
+	{
+		path:        "/version",
+		summary:     "Build information",
+		response:    reflect.TypeOf(BuildInfo{}),
+		description: "Version, commit and build date of the running binary",
+	},
+}
+
+func queryParam(name, description string) map[string]any {
+	return map[string]any{
+		"name":        name,
+		"in":          "query",
+		"required":    false,
+		"description": description,
+		"schema":      map[string]any{"type": "string"},
+	}
+}
+
+func (s *Server) openAPIHandler(c *fiber.Ctx) error {
+	return c.JSON(buildOpenAPI())
+}
+
+// buildOpenAPI assembles an OpenAPI 3 document for apiOperations
+func buildOpenAPI() map[string]any {
+	schemas := make(map[string]any)
+	paths := make(map[string]any)
+
+	for _, op := range apiOperations {
+		method := op.method
+		if method == "" {
+			method = fiber.MethodGet
+		}
+		status := op.status
+		if status == 0 {
+			status = fiber.StatusOK
+		}
+		content := map[string]any{"type": "string"}
+		contentType := op.contentType
+		if contentType == "" {
+			contentType = fiber.MIMEApplicationJSON
+			content = schemaFor(op.response, schemas)
+			if len(op.alternatives) > 0 {
+				oneOf := []any{content}
+				for _, t := range op.alternatives {
+					oneOf = append(oneOf, schemaFor(t, schemas))
+				}
+				content = map[string]any{"oneOf": oneOf}
+			}
+		}
+
+		operation := map[string]any{
+			"summary": op.summary,
+			"responses": map[string]any{
+				strconv.Itoa(status): map[string]any{
+					"description": op.description,
+					"content": map[string]any{
+						contentType: map[string]any{
+							"schema": content,
+						},
+					},
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": schemaFor(apiErrorType, schemas),
+						},
+					},
+				},
+			},
+		}
+		if len(op.parameters) > 0 {
+			operation["parameters"] = op.parameters
+		}
+		if op.request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					fiber.MIMEApplicationJSON: map[string]any{
+						"schema": schemaFor(op.request, schemas),
+					},
+				},
+			}
+		}
+
+		item, ok := paths[op.path].(map[string]any)
+		if !ok {
+			item = make(map[string]any)
+			paths[op.path] = item
+		}
+		item[strings.ToLower(method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "GOTTH System Monitor API",
+			"version": getBuildInfo().Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	apiErrorType = reflect.TypeOf(APIError{})
+)
+
+// schemaFor returns the JSON schema for t, registering named structs in
+// schemas and referencing them by name
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		ref := map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := schemas[t.Name()]; ok {
+			return ref
+		}
+		// Register before walking the fields so recursive types terminate
+		schemas[t.Name()] = nil
+		schemas[t.Name()] = structSchema(t, schemas)
+		return ref
+	default:
+		return map[string]any{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = schemaFor(field.Type, schemas)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
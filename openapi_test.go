@@ -0,0 +1,47 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestOpenAPICoversRoutes checks every /api route is in apiOperations, so
+// a new endpoint can't ship undocumented, and every operation is routed
+func TestOpenAPICoversRoutes(t *testing.T) {
+	cfg, err := loadTestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newServer(cfg, nil, slog.New(slog.DiscardHandler), fakeSource{})
+	t.Cleanup(s.cancel)
+
+	documented := make(map[string]bool)
+	for _, op := range apiOperations {
+		method := op.method
+		if method == "" {
+			method = fiber.MethodGet
+		}
+		documented[method+" "+op.path] = true
+	}
+
+	routed := make(map[string]bool)
+	for _, route := range s.app.GetRoutes(true) {
+		// Fiber registers a HEAD route beside every GET
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		routed[key] = true
+		if strings.HasPrefix(route.Path, "/api/") && route.Path != "/api/openapi.json" && !documented[key] {
+			t.Errorf("%s is not documented in apiOperations", key)
+		}
+	}
+	for key := range documented {
+		if !routed[key] {
+			t.Errorf("apiOperations documents %s, which is not routed", key)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"system-monitor/console"
+	"system-monitor/metrics"
+
+	"github.com/gofiber/websocket/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// Frame formats a subscriber can receive
+const (
+	// formatHTML is the dashboard's hx-swap-oob fragments
+	formatHTML = "html"
+	// formatCompact is the single-line text summary
+	formatCompact = "compact"
+	// formatJSON is the presented snapshot, as served by /api/metrics
+	formatJSON = "json"
+	// formatProto is the snapshot as a monitorpb.Snapshot, for --grpc-port
+	// streams; it is only rendered while the gRPC server is enabled
+	formatProto = "proto"
+)
+
+// Websocket subprotocols the server speaks, each naming a frame format and
+// the version of it. The upgrade picks the first listed protocol that the
+// client also offers; clients that offer none get the v1 HTML frames, or
+// the ?format= query parameter.
+const (
+	protocolHTMLv1    = "monitor.v1.html"
+	protocolCompactV1 = "monitor.v1.compact"
+	protocolJSONv1    = "monitor.v1.json"
+)
+
+var subprotocols = []string{protocolHTMLv1, protocolCompactV1, protocolJSONv1}
+
+var protocolFormats = map[string]string{
+	protocolHTMLv1:    formatHTML,
+	protocolCompactV1: formatCompact,
+	protocolJSONv1:    formatJSON,
+}
+
+// queryFormat maps the ?format= parameter to a frame format, defaulting to
+// HTML
+func queryFormat(v string) string {
+	switch v {
+	case formatCompact, formatJSON:
+		return v
+	default:
+		return formatHTML
+	}
+}
+
+// alertOnlyParam asks /ws and /events for alert-only delivery: frames are
+// only sent when a metric's health status changes, for background tabs
+const alertOnlyParam = "alert-only"
+
+// queryAlertOnly reads the alert-only query parameter as a boolean
+func queryAlertOnly(v string) bool {
+	alertOnly, _ := strconv.ParseBool(v)
+	return alertOnly
+}
+
+// negotiateFormat returns the frame format for a websocket client from the
+// subprotocol chosen during the upgrade. It fails when the client offered
+// subprotocols but none of them is supported.
+func negotiateFormat(offered, negotiated, query string) (string, error) {
+	if negotiated != "" {
+		return protocolFormats[negotiated], nil
+	}
+	if strings.TrimSpace(offered) != "" {
+		return "", fmt.Errorf("unsupported subprotocol %q (supported: %s)", offered, strings.Join(subprotocols, ", "))
+	}
+	return queryFormat(query), nil
+}
+
+// closeUnsupported closes a connection whose requested subprotocol is not
+// supported, explaining why in the close frame
+func (s *Server) closeUnsupported(conn *websocket.Conn, reason string) {
+	// Close reasons are limited to 123 bytes
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	msg := websocket.FormatCloseMessage(websocket.CloseProtocolError, reason)
+	if err := s.writeMessage(conn, websocket.CloseMessage, msg); err != nil {
+		console.Infof("WebSocket close error: %v", err)
+	}
+}
+
+// renderFrames renders a presented snapshot in every frame format
+func (s *Server) renderFrames(ctx context.Context, snapshot *metrics.Snapshot) map[string][]byte {
+	frames := map[string][]byte{
+		formatHTML:    s.renderFrame(ctx, snapshot),
+		formatCompact: []byte(compactLine(snapshot)),
+	}
+	if data, err := json.Marshal(snapshot.Rounded(s.getConfig().JSONPrecision)); err != nil {
+		console.Errorf("Error encoding JSON frame: %v", err)
+	} else {
+		frames[formatJSON] = data
+	}
+	if s.grpcServer != nil {
+		if data, err := proto.Marshal(protoSnapshot(snapshot)); err != nil {
+			console.Errorf("Error encoding protobuf frame: %v", err)
+		} else {
+			frames[formatProto] = data
+		}
+	}
+	return frames
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"system-monitor/console"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxQuietDuration bounds a maintenance window, so a forgotten one doesn't
+// silence alerts indefinitely
+const maxQuietDuration = 7 * 24 * time.Hour
+
+// quietWindow is a maintenance window during which threshold alerts are
+// suppressed. Metrics are still collected and shown, under a banner. It
+// expires on its own once the end time passes.
+type quietWindow struct {
+	mu     sync.RWMutex
+	until  time.Time
+	reason string
+}
+
+// QuietStatus is returned by the /api/quiet endpoints. Until and Reason are
+// only set while a window is active.
+type QuietStatus struct {
+	Active bool       `json:"active"`
+	Until  *time.Time `json:"until,omitempty"`
+	Reason string     `json:"reason,omitempty"`
+}
+
+// start opens a window lasting until the given time, replacing any active one
+func (q *quietWindow) start(until time.Time, reason string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.until = until
+	q.reason = reason
+}
+
+// end closes the active window early
+func (q *quietWindow) end() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.until = time.Time{}
+	q.reason = ""
+}
+
+// status reports the window active at now
+func (q *quietWindow) status(now time.Time) QuietStatus {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if !now.Before(q.until) {
+		return QuietStatus{}
+	}
+	until := q.until
+	return QuietStatus{Active: true, Until: &until, Reason: q.reason}
+}
+
+// active reports whether alerts are suppressed right now
+func (q *quietWindow) active() bool {
+	return q.status(time.Now()).Active
+}
+
+func (s *Server) quietHandler(c *fiber.Ctx) error {
+	return c.JSON(s.quietStatus())
+}
+
+// quietStartHandler opens a maintenance window for the duration in the
+// required 'duration' query parameter, with an optional 'reason' shown in
+// the banner
+func (s *Server) quietStartHandler(c *fiber.Ctx) error {
+	d, err := time.ParseDuration(c.Query("duration"))
+	if err != nil || d <= 0 || d > maxQuietDuration {
+		return newAPIError(fiber.StatusBadRequest, "invalid 'duration' parameter", fmt.Sprintf("must be a positive duration of at most %s such as 30m", maxQuietDuration))
+	}
+
+	until := time.Now().Add(d).Truncate(time.Second)
+	s.quiet.start(until, c.Query("reason"))
+	console.Printf(console.Quiet, "Alerts suppressed until %s", until.In(s.getConfig().Location).Format(timestampLayout))
+	return c.JSON(s.quietStatus())
+}
+
+// quietEndHandler ends the maintenance window early
+func (s *Server) quietEndHandler(c *fiber.Ctx) error {
+	s.quiet.end()
+	console.Println(console.Resume, "Maintenance window ended, alerts resumed")
+	return c.JSON(s.quietStatus())
+}
+
+// quietStatus reports the maintenance window in the configured time zone
+func (s *Server) quietStatus() QuietStatus {
+	status := s.quiet.status(time.Now())
+	if status.Until != nil {
+		until := status.Until.In(s.getConfig().Location)
+		status.Until = &until
+	}
+	return status
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// reverseDNSTimeout bounds each PTR lookup so a slow resolver only
+	// delays /api/subscribers briefly
+	reverseDNSTimeout = time.Second
+	// reverseDNSTTL is how long a lookup, including a failed one, is reused
+	reverseDNSTTL = 10 * time.Minute
+)
+
+// Names reported for addresses that are never looked up
+const (
+	hostnameLoopback = "loopback"
+	hostnamePrivate  = "private"
+)
+
+// reverseDNS resolves subscriber addresses for --resolve-subscribers,
+// caching the results
+type reverseDNS struct {
+	mu    sync.Mutex
+	cache map[string]reverseDNSEntry
+}
+
+type reverseDNSEntry struct {
+	name    string
+	expires time.Time
+}
+
+func newReverseDNS() *reverseDNS {
+	return &reverseDNS{cache: make(map[string]reverseDNSEntry)}
+}
+
+// lookup returns the name for a host:port remote address. Loopback and
+// private addresses are labelled as such without a lookup; an address that
+// doesn't resolve yields an empty name.
+func (r *reverseDNS) lookup(ctx context.Context, remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return ""
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.IsLoopback():
+		return hostnameLoopback
+	case ip.IsPrivate() || ip.IsLinkLocalUnicast():
+		return hostnamePrivate
+	}
+
+	r.mu.Lock()
+	entry, ok := r.cache[host]
+	r.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.name
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reverseDNSTimeout)
+	defer cancel()
+	var name string
+	if names, err := net.DefaultResolver.LookupAddr(ctx, host); err == nil && len(names) > 0 {
+		name = strings.TrimSuffix(names[0], ".")
+	}
+
+	r.mu.Lock()
+	r.cache[host] = reverseDNSEntry{name: name, expires: time.Now().Add(reverseDNSTTL)}
+	r.mu.Unlock()
+	return name
+}
+
+// annotate fills in the hostname of every subscriber, resolving them in
+// parallel so one slow lookup doesn't add up across clients
+func (r *reverseDNS) annotate(ctx context.Context, infos []SubscriberInfo) {
+	var wg sync.WaitGroup
+	for i := range infos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			infos[i].Hostname = r.lookup(ctx, infos[i].RemoteAddr)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+
+	"system-monitor/console"
+	"system-monitor/templates"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statusStarting is the JSON frame and /readyz body sent during warm-up
+const statusStarting = `{"status":"starting"}`
+
+// warmedUp reports whether a snapshot should be published, counting down
+// --warmup-ticks once every enabled collector has a value. Rates such as CPU
+// and network usage need a previous reading, so the first complete
+// snapshots still carry zeros. Only the publisher calls it.
+func (s *Server) warmedUp(warmup *int) bool {
+	if s.ready.Load() {
+		return true
+	}
+	if !s.collectors.collected() {
+		return false
+	}
+	if *warmup > 0 {
+		*warmup--
+		return false
+	}
+	s.ready.Store(true)
+	console.Println(console.Ready, "Collectors warmed up, publishing metrics")
+	return true
+}
+
+// startingFrame returns the placeholder sent to clients that connect before
+// the first frame is published
+func startingFrame(ctx context.Context, format string) []byte {
+	switch format {
+	case formatCompact:
+		return []byte("starting up")
+	case formatJSON:
+		return []byte(statusStarting)
+	case formatProto:
+		// gRPC streams have no notice to send; they wait for the first frame
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`<div hx-swap-oob="innerHTML:#update-timestamp">`)
+	if err := templates.StatusStarting().Render(ctx, &buf); err != nil {
+		console.Errorf("Error rendering status component: %v", err)
+		return nil
+	}
+	buf.WriteString(`</div>`)
+	return buf.Bytes()
+}
+
+// ReadyStatus is the /readyz body once the publisher has warmed up; during
+// warm-up it is statusStarting
+type ReadyStatus struct {
+	Status string `json:"status"`
+}
+
+// readyzHandler reports 503 until the publisher has warmed up, so load
+// balancers and orchestrators hold traffic until frames carry real values
+func (s *Server) readyzHandler(c *fiber.Ctx) error {
+	if !s.ready.Load() {
+		c.Status(fiber.StatusServiceUnavailable).Type("json")
+		return c.SendString(statusStarting)
+	}
+	return c.JSON(ReadyStatus{Status: "ready"})
+}
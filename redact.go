@@ -0,0 +1,38 @@
+package main
+
+import (
+	"system-monitor/metrics"
+)
+
+const redactedPlaceholder = "[redacted]"
+
+// apiSnapshot returns the snapshot as JSON clients should see it: presented,
+// with percentages rounded to --json-precision
+func (s *Server) apiSnapshot(snapshot *metrics.Snapshot) *metrics.Snapshot {
+	return s.presentable(snapshot).Rounded(s.getConfig().JSONPrecision)
+}
+
+// presentable returns the snapshot as clients should see it. With --redact
+// the identifying host fields are masked, and --hostname-label replaces the
+// displayed hostname; collectors and thresholds keep working on the real
+// values. An explicit label is shown even when redacting, since it was
+// chosen to be published.
+func (s *Server) presentable(snapshot *metrics.Snapshot) *metrics.Snapshot {
+	cfg := s.getConfig()
+	if snapshot == nil || snapshot.System == nil || (!cfg.Redact && cfg.HostnameLabel == "") {
+		return snapshot
+	}
+
+	masked := *snapshot
+	system := *snapshot.System
+	if cfg.Redact {
+		system.Hostname = redactedPlaceholder
+		system.Platform = redactedPlaceholder
+	}
+	if cfg.HostnameLabel != "" {
+		system.Hostname = cfg.HostnameLabel
+	}
+	masked.System = &system
+
+	return &masked
+}
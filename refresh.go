@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errStartingUp is returned by a publish attempt during warm-up
+var errStartingUp = errors.New("collectors are still starting up")
+
+// refreshResult is the publisher's reply to a refresh request: the sequence
+// number of the snapshot it published, as used by /api/metrics?since=
+type refreshResult struct {
+	seq uint64
+	err error
+}
+
+// RefreshResponse is returned by POST /api/refresh
+type RefreshResponse struct {
+	Seq   uint64 `json:"seq"`
+	Token string `json:"token"`
+}
+
+// refreshHandler makes the publisher collect from every collector and
+// broadcast a frame immediately, out of band with the ticker. It answers
+// 202 with the new snapshot's sequence number and metrics token.
+func (s *Server) refreshHandler(c *fiber.Ctx) error {
+	if s.replay != nil {
+		return newAPIError(fiber.StatusConflict, "replaying a recording", "the monitor was started with --replay and collects nothing to refresh")
+	}
+	reply := make(chan refreshResult, 1)
+	select {
+	case s.refreshRequests <- reply:
+	case <-c.Context().Done():
+		return newAPIError(fiber.StatusServiceUnavailable, "refresh cancelled", "")
+	case <-s.ctx.Done():
+		return newAPIError(fiber.StatusServiceUnavailable, "shutting down", "")
+	}
+
+	result := <-reply
+	if errors.Is(result.err, errStartingUp) {
+		return newAPIError(fiber.StatusServiceUnavailable, "not ready", result.err.Error())
+	}
+	if result.err != nil {
+		return newAPIError(fiber.StatusInternalServerError, "collecting metrics failed", result.err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(RefreshResponse{
+		Seq:   result.seq,
+		Token: s.deltas.token(result.seq),
+	})
+}
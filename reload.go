@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"system-monitor/console"
+)
+
+// reloadableFlags are the settings applied on SIGHUP without a restart. They
+// are read from the current configuration each time they are used.
+var reloadableFlags = []string{
+	"interval",
+	"intervals",
+	"publish-interval",
+	"health-thresholds",
+	"net-view",
+	"net-iface",
+	"cpu-layout",
+	"rolling-window",
+	"timezone",
+	"max-processes",
+	"process-grouping",
+	"process-sort",
+	"process-io",
+	"zombie-warning",
+	"custom-timeout",
+	"clock-skew-warning",
+	"max-frame-bytes",
+	"frame-template",
+	"log-level",
+	"json-precision",
+	"load-precision",
+	"ws-write-timeout",
+	"subscriber-warning",
+}
+
+// getConfig returns the current configuration; a reload swaps in a new one
+func (s *Server) getConfig() *Config {
+	return s.config.Load()
+}
+
+// watchReload reloads the configuration whenever the process receives
+// SIGHUP
+func (s *Server) watchReload() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			s.reloadConfig()
+		}
+	}()
+}
+
+// reloadConfig re-reads the command line and --config file. Changes to
+// reloadableFlags take effect immediately; any other change is logged as
+// needing a restart and the running value is kept. An invalid configuration
+// is rejected as a whole.
+func (s *Server) reloadConfig() {
+	next, err := loadConfig(os.Args[1:], flag.ContinueOnError)
+	if err != nil {
+		console.Errorf("Error reloading configuration: %v", err)
+		return
+	}
+
+	current := s.getConfig()
+	var changed, restart []string
+	for name, value := range next.flagValues {
+		old := current.flagValues[name]
+		if value == old {
+			continue
+		}
+		if slices.Contains(reloadableFlags, name) {
+			changed = append(changed, fmt.Sprintf("--%s %q → %q", name, old, value))
+		} else {
+			restart = append(restart, "--"+name)
+		}
+	}
+	slices.Sort(changed)
+	slices.Sort(restart)
+
+	applied := *current
+	applied.Interval = next.Interval
+	applied.Intervals = next.Intervals
+	applied.PublishInterval = next.PublishInterval
+	applied.Health = next.Health
+	applied.NetView = next.NetView
+	applied.NetIfaces = next.NetIfaces
+	applied.CPULayout = next.CPULayout
+	applied.RollingWindow = next.RollingWindow
+	applied.Location = next.Location
+	applied.MaxProcesses = next.MaxProcesses
+	applied.ProcessGrouping = next.ProcessGrouping
+	applied.ProcessSort = next.ProcessSort
+	applied.ProcessIO = next.ProcessIO
+	applied.ZombieWarning = next.ZombieWarning
+	applied.CustomTimeout = next.CustomTimeout
+	applied.ClockSkewWarn = next.ClockSkewWarn
+	applied.MaxFrameBytes = next.MaxFrameBytes
+	applied.FrameTemplate = next.FrameTemplate
+	applied.JSONPrecision = next.JSONPrecision
+	applied.LoadPrecision = next.LoadPrecision
+	applied.WSWriteTimeout = next.WSWriteTimeout
+	applied.SubsWarning = next.SubsWarning
+	applied.LogLevel = next.LogLevel
+	applied.flagValues = make(map[string]string, len(current.flagValues))
+	for name, value := range current.flagValues {
+		applied.flagValues[name] = value
+		if slices.Contains(reloadableFlags, name) {
+			applied.flagValues[name] = next.flagValues[name]
+		}
+	}
+	s.config.Store(&applied)
+	s.collectors.setIntervals(s.collectorInterval)
+	logLevel.Set(applied.LogLevel)
+
+	// The --frame-template file is re-read even when its path is unchanged,
+	// so an edited layout is picked up
+	if path := next.flagValues["frame-template"]; path != "" && path == current.flagValues["frame-template"] {
+		changed = append(changed, fmt.Sprintf("--frame-template %s re-read", path))
+	}
+	switch {
+	case len(changed) > 0:
+		console.Printf(console.Reload, "Configuration reloaded: %s", strings.Join(changed, ", "))
+	case len(restart) == 0:
+		console.Println(console.Reload, "Configuration reloaded, nothing changed")
+	}
+	if len(restart) > 0 {
+		console.Printf(console.Reload, "Restart to apply: %s", strings.Join(restart, ", "))
+	}
+}
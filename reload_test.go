@@ -0,0 +1,43 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReloadConfig(t *testing.T) {
+	layout := filepath.Join(t.TempDir(), "frame.html")
+	if err := os.WriteFile(layout, []byte(`<div id="before"></div>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--frame-template", layout}
+	s, _ := newTestServer(t, args...)
+
+	argv := os.Args
+	os.Args = append([]string{argv[0]}, loadTestArgs(args...)...)
+	t.Cleanup(func() {
+		os.Args = argv
+		logLevel.Set(slog.LevelInfo)
+	})
+
+	// Edit the layout in place, so no flag changes
+	if err := os.WriteFile(layout, []byte(`<div id="after"></div>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s.reloadConfig()
+	if got := s.getConfig().FrameTemplate.Tree.Root.String(); !strings.Contains(got, "after") {
+		t.Errorf("frame template after reload = %s, want the edited file", got)
+	}
+
+	os.Args = append(os.Args, "--log-level=warn")
+	s.reloadConfig()
+	if got := logLevel.Level(); got != slog.LevelWarn {
+		t.Errorf("log level after reload = %v, want WARN", got)
+	}
+	if got := s.getConfig().LogLevel; got != slog.LevelWarn {
+		t.Errorf("configured log level after reload = %v, want WARN", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/metrics"
+)
+
+// replay plays a recorded --ndjson-file back through the publisher in place
+// of the collectors, for demos and UI work without a live system
+type replay struct {
+	snapshots []metrics.Snapshot
+	speed     float64
+	loop      bool
+}
+
+// startReplay publishes the recorded snapshots with their original spacing
+// divided by --replay-speed, stamped with the current time so the history
+// charts keep moving forward. With --replay-loop it starts over after the
+// last one, one --interval later; otherwise the last frame stays up.
+func (s *Server) startReplay() {
+	r := s.replay
+	s.ready.Store(true)
+	console.Printf(console.Start, "Replaying %d recorded snapshots at %gx speed", len(r.snapshots), r.speed)
+
+	go func() {
+		state := &publishState{interval: s.getConfig().Interval}
+		for {
+			for i := range r.snapshots {
+				gap := s.getConfig().Interval
+				if i > 0 {
+					gap = r.snapshots[i].Time.Sub(r.snapshots[i-1].Time)
+				}
+				timer := time.NewTimer(time.Duration(float64(max(gap, 0)) / r.speed))
+				select {
+				case <-s.ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+
+				snapshot := r.snapshots[i]
+				snapshot.Time = time.Now()
+				s.publishSnapshot(state, &snapshot, snapshot.Time, false)
+			}
+			if !r.loop {
+				console.Infof("Replay finished, keeping the last frame")
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Collectors retry transient read errors a few times before giving up on
+// the tick. Each wait is collectRetryDelay plus up to the same again in
+// jitter, so collectors sharing a cadence don't retry in lockstep.
+const (
+	collectRetries    = 2
+	collectRetryDelay = 50 * time.Millisecond
+)
+
+// isTransient reports whether err is a read failure worth retrying, such as
+// an interrupted or timed-out read of /proc. Missing files, permission
+// errors and anything unrecognised are treated as permanent.
+func isTransient(err error) bool {
+	switch {
+	case errors.Is(err, syscall.EINTR),
+		errors.Is(err, syscall.EAGAIN),
+		errors.Is(err, syscall.EBUSY),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, os.ErrDeadlineExceeded),
+		errors.Is(err, context.DeadlineExceeded):
+		return true
+	default:
+		return false
+	}
+}
+
+// isPermissionDenied reports whether err means the monitor lacks the
+// privileges to read a source, as opposed to the source being unsupported
+func isPermissionDenied(err error) bool {
+	return errors.Is(err, os.ErrPermission)
+}
+
+// collectWithRetry calls collect, retrying transient errors up to
+// collectRetries times with a jittered delay. The last error is returned
+// when every attempt fails.
+func collectWithRetry[T any](collect func() (T, error)) (T, error) {
+	value, err := collect()
+	for attempt := 0; attempt < collectRetries && err != nil && isTransient(err); attempt++ {
+		time.Sleep(collectRetryDelay + rand.N(collectRetryDelay))
+		value, err = collect()
+	}
+	return value, err
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+)
+
+// fakeCollector fails with errs in turn, then returns its call count
+type fakeCollector struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeCollector) collect() (int, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return 0, f.errs[f.calls-1]
+	}
+	return f.calls, nil
+}
+
+func TestCollectWithRetry(t *testing.T) {
+	transient := fmt.Errorf("read /proc/stat: %w", syscall.EINTR)
+	tests := []struct {
+		name      string
+		errs      []error
+		wantValue int
+		wantErr   error
+		wantCalls int
+	}{
+		{"success", nil, 1, nil, 1},
+		{"transient then success", []error{transient}, 2, nil, 2},
+		{"permanent", []error{os.ErrNotExist}, 0, os.ErrNotExist, 1},
+		{"permission denied", []error{fmt.Errorf("open /proc/1/io: %w", os.ErrPermission)}, 0, os.ErrPermission, 1},
+		{"transient then permanent", []error{transient, os.ErrNotExist}, 0, os.ErrNotExist, 2},
+		{"exhausted", []error{transient, transient, syscall.EAGAIN}, 0, syscall.EAGAIN, collectRetries + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeCollector{errs: tt.errs}
+			value, err := collectWithRetry(f.collect)
+			if value != tt.wantValue {
+				t.Errorf("value = %d, want %d", value, tt.wantValue)
+			}
+			if tt.wantErr == nil && err != nil || !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if f.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", f.calls, tt.wantCalls)
+			}
+		})
+	}
+}
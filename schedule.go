@@ -0,0 +1,62 @@
+package main
+
+import "time"
+
+// publishSchedule fires the publisher's ticks once per interval. By default
+// it is a plain ticker, which keeps the cadence but drifts from whatever
+// instant the monitor started at. With --align-ticks every tick is armed
+// for the next multiple of the interval on the wall clock, so monitors on
+// different hosts publish at the same instants.
+type publishSchedule struct {
+	align    bool
+	interval time.Duration
+	ticker   *time.Ticker
+	timer    *time.Timer
+}
+
+func newPublishSchedule(interval time.Duration, align bool) *publishSchedule {
+	p := &publishSchedule{align: align, interval: interval}
+	if align {
+		p.timer = time.NewTimer(time.Until(nextAligned(time.Now(), interval)))
+	} else {
+		p.ticker = time.NewTicker(interval)
+	}
+	return p
+}
+
+// C delivers the ticks
+func (p *publishSchedule) C() <-chan time.Time {
+	if p.align {
+		return p.timer.C
+	}
+	return p.ticker.C
+}
+
+// rearm schedules the tick after the one just received, at an interval a
+// reload may have changed
+func (p *publishSchedule) rearm(interval time.Duration) {
+	if p.align {
+		p.interval = interval
+		p.timer.Reset(time.Until(nextAligned(time.Now(), interval)))
+		return
+	}
+	if interval != p.interval {
+		p.interval = interval
+		p.ticker.Reset(interval)
+	}
+}
+
+func (p *publishSchedule) stop() {
+	if p.align {
+		p.timer.Stop()
+	} else {
+		p.ticker.Stop()
+	}
+}
+
+// nextAligned returns the first whole multiple of interval after now, e.g.
+// :00, :02, :04 for 2s. A boundary missed by a slow tick is skipped rather
+// than fired late.
+func nextAligned(now time.Time, interval time.Duration) time.Time {
+	return now.Truncate(interval).Add(interval)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// shareParam carries a share token on the link handed out by /api/share
+	shareParam = "share"
+	// shareCookie keeps the token for the dashboard's own stream and fetch
+	// requests once the link has been opened
+	shareCookie = "monitor_share"
+)
+
+// openPaths stay reachable without credentials under --protect-dashboard
+var openPaths = []string{"/static/", "/readyz", "/version"}
+
+// adminPaths are never granted by a share token, only by the admin
+// credentials
+var adminPaths = []string{"/admin", "/api/subscribers", "/api/refresh", "/api/share", "/api/logs", "/api/quiet"}
+
+var (
+	errShareMalformed = errors.New("malformed share token")
+	errShareSignature = errors.New("invalid share token signature")
+	errShareExpired   = errors.New("share token expired")
+)
+
+// ShareResponse is returned by POST /api/share
+type ShareResponse struct {
+	URL     string    `json:"url"`
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+// newShareToken signs an expiry time as "<unix seconds>.<signature>"
+func newShareToken(secret string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + shareSignature(secret, exp)
+}
+
+// verifyShareToken checks a token's signature and returns its expiry, or an
+// error when it is malformed, forged or expired at now
+func verifyShareToken(secret, token string, now time.Time) (time.Time, error) {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return time.Time{}, errShareMalformed
+	}
+	secs, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return time.Time{}, errShareMalformed
+	}
+	if !hmac.Equal([]byte(sig), []byte(shareSignature(secret, exp))) {
+		return time.Time{}, errShareSignature
+	}
+	expires := time.Unix(secs, 0)
+	if !now.Before(expires) {
+		return time.Time{}, errShareExpired
+	}
+	return expires, nil
+}
+
+func shareSignature(secret, exp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("share:" + exp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// dashboardAuth guards the dashboard, its streams and the metrics endpoints
+// with the admin credentials under --protect-dashboard. A valid share token,
+// from the link's query parameter or the cookie set when it was opened,
+// grants read-only access instead; it is checked on every request, so the
+// link stops working once it expires.
+func (s *Server) dashboardAuth() fiber.Handler {
+	cfg := s.getConfig()
+	credentials := s.adminAuth(true)
+	return func(c *fiber.Ctx) error {
+		if !cfg.Protect || hasPathPrefix(c.Path(), openPaths) {
+			return c.Next()
+		}
+		if cfg.ShareSecret == "" || !readOnly(c) || hasPathPrefix(c.Path(), adminPaths) {
+			return credentials(c)
+		}
+
+		token, fromQuery := c.Query(shareParam), true
+		if token == "" {
+			token, fromQuery = c.Cookies(shareCookie), false
+		}
+		if token == "" {
+			return credentials(c)
+		}
+		expires, err := verifyShareToken(cfg.ShareSecret, token, time.Now())
+		if err != nil {
+			c.ClearCookie(shareCookie)
+			return newAPIError(fiber.StatusUnauthorized, "unauthorized", err.Error())
+		}
+		if fromQuery {
+			c.Cookie(&fiber.Cookie{
+				Name:     shareCookie,
+				Value:    token,
+				Path:     "/",
+				Expires:  expires,
+				HTTPOnly: true,
+				SameSite: fiber.CookieSameSiteLaxMode,
+			})
+		}
+		return c.Next()
+	}
+}
+
+// readOnly reports whether the request only reads, which is all a share
+// token permits
+func readOnly(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// shareHandler signs a share link valid for --share-ttl, or for the
+// duration in the optional 'ttl' query parameter
+func (s *Server) shareHandler(c *fiber.Ctx) error {
+	cfg := s.getConfig()
+	if cfg.ShareSecret == "" || !cfg.Protect {
+		return newAPIError(fiber.StatusForbidden, "share links not configured", "start the monitor with --protect-dashboard and --share-secret to enable this endpoint")
+	}
+
+	ttl := cfg.ShareTTL
+	if v := c.Query("ttl"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return newAPIError(fiber.StatusBadRequest, "invalid 'ttl' parameter", "must be a positive duration such as 24h")
+		}
+		ttl = d
+	}
+
+	expires := time.Now().Add(ttl).Truncate(time.Second)
+	token := newShareToken(cfg.ShareSecret, expires)
+	return c.JSON(ShareResponse{
+		URL:     c.BaseURL() + "/?" + shareParam + "=" + token,
+		Token:   token,
+		Expires: expires.In(cfg.Location),
+	})
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"system-monitor/console"
+)
+
+// shutdownTimeout bounds how long open requests get to finish on shutdown
+const shutdownTimeout = 5 * time.Second
+
+// shutdownOnSignal waits for SIGINT or SIGTERM, then shuts the server down
+func (s *Server) shutdownOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	signal.Stop(signals)
+
+	console.Println(console.Stop, "Shutting down")
+	s.shutdown()
+}
+
+// shutdown cancels the server's context so in-flight renders and live
+// streams stop, and shuts the listeners down
+func (s *Server) shutdown() {
+	s.cancel()
+	if err := s.app.ShutdownWithTimeout(shutdownTimeout); err != nil {
+		console.Errorf("Error shutting down: %v", err)
+	}
+	s.stopGRPC()
+}
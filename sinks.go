@@ -0,0 +1,71 @@
+package main
+
+import (
+	"system-monitor/console"
+	"system-monitor/metrics"
+)
+
+// publishSink is one output of the publisher. Throttled sinks only get the
+// snapshots --publish-interval lets through; the rest get every tick.
+type publishSink struct {
+	metrics.Sink
+	throttled bool
+}
+
+// streamSink broadcasts frames to the websocket and SSE subscribers
+type streamSink struct {
+	s *Server
+}
+
+func (k streamSink) Publish(snapshot *metrics.Snapshot) {
+	s := k.s
+	presented := s.presentable(snapshot)
+	levels := metrics.EvaluateLevels(presented.System, presented.CPU, presented.Disk, s.getConfig().Health)
+	s.publishMsg(s.renderFrames(s.ctx, presented), levels)
+}
+
+// snapshotFileSink rewrites --snapshot-file
+type snapshotFileSink struct {
+	s *Server
+}
+
+func (k snapshotFileSink) Publish(snapshot *metrics.Snapshot) {
+	if err := k.s.writeSnapshotFile(k.s.ctx, k.s.presentable(snapshot)); err != nil {
+		console.Errorf("Error writing snapshot file: %v", err)
+	}
+}
+
+// newSinks assembles the outputs enabled by the configuration. The live
+// streams are always on; the exports are added by their flags.
+func (s *Server) newSinks(cfg *Config) []publishSink {
+	sinks := []publishSink{{Sink: streamSink{s}, throttled: true}}
+
+	if cfg.SnapshotFile != "" {
+		sinks = append(sinks, publishSink{Sink: snapshotFileSink{s}, throttled: true})
+	}
+
+	if cfg.Influx.URL != "" {
+		if writer, err := metrics.NewInfluxWriter(cfg.Influx); err != nil {
+			console.Errorf("Error configuring InfluxDB export: %v", err)
+		} else {
+			sinks = append(sinks, publishSink{Sink: writer})
+			console.Printf(console.Export, "Exporting metrics to InfluxDB at %s", cfg.Influx.URL)
+		}
+	}
+
+	if cfg.PushGateway != "" {
+		sinks = append(sinks, publishSink{Sink: metrics.NewPushGateway(cfg.PushGateway, cfg.HostLabel)})
+		console.Printf(console.Export, "Pushing metrics to the Pushgateway at %s", cfg.PushGateway)
+	}
+
+	if cfg.NDJSONFile != "" {
+		if writer, err := metrics.NewNDJSONWriter(cfg.NDJSONFile); err != nil {
+			console.Errorf("Error opening NDJSON export: %v", err)
+		} else {
+			sinks = append(sinks, publishSink{Sink: writer})
+			console.Printf(console.Export, "Appending metrics to %s as NDJSON", cfg.NDJSONFile)
+		}
+	}
+
+	return sinks
+}
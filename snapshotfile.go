@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"system-monitor/metrics"
+	"system-monitor/templates"
+
+	"github.com/a-h/templ"
+)
+
+// writeSnapshotFile renders the dashboard with every panel filled in and no
+// streaming scripts, then atomically replaces --snapshot-file with it so
+// readers never see a partial page
+func (s *Server) writeSnapshotFile(ctx context.Context, snapshot *metrics.Snapshot) error {
+	panels := s.framePanels(snapshot)
+	if snapshot.Processes != nil {
+		panels = append(panels, processPanel(snapshot.Processes))
+	}
+
+	var page bytes.Buffer
+	if err := staticIndex(ctx, s.indexOptions(), panels).Render(ctx, &page); err != nil {
+		return err
+	}
+
+	path := s.getConfig().SnapshotFile
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp makes the file private; the snapshot is meant to be served
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(page.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// staticIndex returns the dashboard page with every panel prefilled and no
+// streaming scripts
+func staticIndex(ctx context.Context, opts templates.IndexOptions, panels []panel) templ.Component {
+	opts.Static = true
+	opts.Panels = make(map[string]templ.Component)
+	for _, p := range panels {
+		opts.Panels[p.id] = templ.Raw(string(renderPanelContent(ctx, p)))
+	}
+	return templates.Index(opts)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"time"
+
+	"system-monitor/console"
+	"system-monitor/telemetry"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sseKeepaliveInterval is how often a stream with nothing to send writes a
+// comment, so that a client which went away is noticed by the failed flush
+// even when no frames are published for it, as with an alert-only stream
+const sseKeepaliveInterval = 15 * time.Second
+
+// eventsHandler streams published frames as Server-Sent Events
+func (s *Server) eventsHandler(c *fiber.Ctx) error {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	// Stop nginx and similar proxies from buffering the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	// The stream outlives the handler, so its context is cancelled by the
+	// stream writer rather than deferred here
+	streamCtx, cancel := context.WithCancel(s.ctx)
+	subscriber := &Subscriber{
+		msgs:        make(chan streamMessage, s.subscriberMessageBuffer),
+		done:        make(chan struct{}),
+		ctx:         streamCtx,
+		format:      queryFormat(c.Query("format")),
+		alertOnly:   queryAlertOnly(c.Query(alertOnlyParam)),
+		transport:   telemetry.TransportSSE,
+		remoteAddr:  c.Context().RemoteAddr().String(),
+		connectedAt: time.Now(),
+	}
+	s.addSubscriber(subscriber)
+
+	s.telemetry.StreamConnected(telemetry.TransportSSE)
+
+	ctx := c.Context()
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		defer s.removeSubscriber(subscriber)
+		defer s.telemetry.StreamDisconnected(telemetry.TransportSSE)
+
+		console.Infof("SSE connection established")
+
+		keepalive := time.NewTicker(sseKeepaliveInterval)
+		defer keepalive.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-streamCtx.Done():
+				return
+			case <-subscriber.done:
+				return
+			case msg := <-subscriber.msgs:
+				writeEvent(w, msg.data)
+				// fasthttp only cancels the request context on shutdown, so a
+				// failed flush is how a client disconnect surfaces
+				if err := w.Flush(); err != nil {
+					console.Infof("SSE write error: %v", err)
+					return
+				}
+			case <-keepalive.C:
+				if err := writeKeepalive(w); err != nil {
+					console.Infof("SSE write error: %v", err)
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent writes msg as a single SSE message event, prefixing each line
+// with "data:" as the protocol requires for multi-line payloads
+func writeEvent(w *bufio.Writer, msg []byte) {
+	w.WriteString("event: message\n")
+	for _, line := range bytes.Split(msg, []byte("\n")) {
+		w.WriteString("data: ")
+		w.Write(line)
+		w.WriteByte('\n')
+	}
+	w.WriteByte('\n')
+}
+
+// writeKeepalive writes an SSE comment, which clients ignore, and flushes it
+func writeKeepalive(w *bufio.Writer) error {
+	w.WriteString(": keepalive\n\n")
+	return w.Flush()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"time"
+
+	"system-monitor/console"
+)
+
+const (
+	// subscriberCheckInterval is how often watchSubscribers checks the
+	// subscriber count
+	subscriberCheckInterval = time.Minute
+	// subscriberClimbChecks is how many checks in a row the count must rise
+	// before it is reported as climbing
+	subscriberClimbChecks = 10
+)
+
+// subscriberGuard tracks the subscriber count between checks, so each
+// anomaly is logged when it starts rather than every minute
+type subscriberGuard struct {
+	previous  int
+	climbing  int
+	overBound bool
+	// orphaned is the excess of subscribers over open streams at the last
+	// check; a stream closing between the two reads makes one check
+	// disagree, so only an excess seen twice is reported
+	orphaned int
+	reported bool
+}
+
+// watchSubscribers checks the subscribers map against --subscriber-warning
+// and the open stream connections until shutdown. It is a safety net: a
+// subscriber left behind by a closed connection would otherwise grow the
+// map unnoticed.
+func (s *Server) watchSubscribers() {
+	ticker := time.NewTicker(subscriberCheckInterval)
+	defer ticker.Stop()
+
+	var guard subscriberGuard
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			guard.check(s.subscriberCount(), s.telemetry.OpenStreams(), s.getConfig().SubsWarning)
+		}
+	}
+}
+
+// check logs the anomalies in count: above bound (when positive), above the
+// open stream connections, or rising for subscriberClimbChecks in a row
+func (g *subscriberGuard) check(count, open, bound int) {
+	over := bound > 0 && count > bound
+	if over && !g.overBound {
+		console.Printf(console.Warning, "%d live stream subscribers exceed --subscriber-warning %d", count, bound)
+	}
+	g.overBound = over
+
+	excess := count - open
+	if excess > 0 && g.orphaned > 0 && !g.reported {
+		console.Printf(console.Warning, "%d subscribers are registered but only %d streams are open; closed connections are not being removed", count, open)
+		g.reported = true
+	}
+	if excess <= 0 {
+		g.reported = false
+	}
+	g.orphaned = excess
+
+	if count > g.previous {
+		g.climbing++
+		if g.climbing == subscriberClimbChecks {
+			console.Printf(console.Warning, "Subscriber count has risen for %d checks in a row, to %d", g.climbing, count)
+		}
+	} else {
+		g.climbing = 0
+	}
+	g.previous = count
+}
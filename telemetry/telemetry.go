@@ -0,0 +1,200 @@
+package telemetry
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Subscriber transports, used as the "transport" label
+const (
+	TransportWebSocket = "websocket"
+	TransportSSE       = "sse"
+	TransportGRPC      = "grpc"
+)
+
+// Series exposed on /metrics, also referenced by the Grafana dashboard
+const (
+	MetricRequests    = "monitor_http_requests_total"
+	MetricConnects    = "monitor_stream_connects_total"
+	MetricDisconnects = "monitor_stream_disconnects_total"
+	MetricSubscribers = "monitor_subscribers"
+	MetricStreamsOpen = "monitor_streams_open"
+)
+
+// Telemetry records how the dashboard itself is used
+type Telemetry struct {
+	registry    *prometheus.Registry
+	started     time.Time
+	requests    *prometheus.CounterVec
+	connects    *prometheus.CounterVec
+	disconnects *prometheus.CounterVec
+	// open counts the stream handlers still running, against which the
+	// subscriber count is checked
+	open atomic.Int64
+}
+
+// New creates the telemetry registry. subscriberCount reports the number of
+// connected live stream clients when the registry is scraped; host, when
+// set, is attached to every series as the host label.
+func New(subscriberCount func() int, host string) *Telemetry {
+	t := &Telemetry{
+		registry: prometheus.NewRegistry(),
+		started:  time.Now(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricRequests,
+			Help: "HTTP requests served, by method and status code.",
+		}, []string{"method", "code"}),
+		connects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricConnects,
+			Help: "Live stream clients that connected, by transport.",
+		}, []string{"transport"}),
+		disconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricDisconnects,
+			Help: "Live stream clients that disconnected, by transport.",
+		}, []string{"transport"}),
+	}
+
+	var registerer prometheus.Registerer = t.registry
+	if host != "" {
+		registerer = prometheus.WrapRegistererWith(prometheus.Labels{"host": host}, t.registry)
+	}
+
+	registerer.MustRegister(
+		t.requests,
+		t.connects,
+		t.disconnects,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: MetricSubscribers,
+			Help: "Live stream clients currently connected.",
+		}, func() float64 { return float64(subscriberCount()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: MetricStreamsOpen,
+			Help: "Live stream connections whose handlers are still running; above monitor_subscribers only briefly.",
+		}, func() float64 { return float64(t.open.Load()) }),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return t
+}
+
+// StatusCoder is implemented by handler errors that carry the HTTP status
+// the error handler answers them with
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Middleware counts every request by method and final status code
+func (t *Telemetry) Middleware(c *fiber.Ctx) error {
+	err := c.Next()
+
+	code := c.Response().StatusCode()
+	if err != nil {
+		code = fiber.StatusInternalServerError
+		var fiberErr *fiber.Error
+		var coder StatusCoder
+		if errors.As(err, &fiberErr) {
+			code = fiberErr.Code
+		} else if errors.As(err, &coder) {
+			code = coder.StatusCode()
+		}
+	}
+	t.requests.WithLabelValues(c.Method(), strconv.Itoa(code)).Inc()
+
+	return err
+}
+
+// StreamConnected records a live stream client connecting
+func (t *Telemetry) StreamConnected(transport string) {
+	t.connects.WithLabelValues(transport).Inc()
+	t.open.Add(1)
+}
+
+// StreamDisconnected records a live stream client disconnecting
+func (t *Telemetry) StreamDisconnected(transport string) {
+	t.disconnects.WithLabelValues(transport).Inc()
+	t.open.Add(-1)
+}
+
+// OpenStreams returns the number of live stream connections still open
+func (t *Telemetry) OpenStreams() int {
+	return int(t.open.Load())
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (t *Telemetry) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(t.registry, promhttp.HandlerOpts{}))
+}
+
+// UsageStats summarizes dashboard usage for the admin page
+type UsageStats struct {
+	Since       time.Time
+	Requests    float64
+	StatusCodes []StatusCount
+	Connects    map[string]float64
+	Disconnects map[string]float64
+}
+
+// StatusCount is the number of responses sent with one status code
+type StatusCount struct {
+	Code  string
+	Count float64
+}
+
+// Usage reads the current counter values back out of the registry
+func (t *Telemetry) Usage() (*UsageStats, error) {
+	families, err := t.registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &UsageStats{
+		Since:       t.started,
+		Connects:    make(map[string]float64),
+		Disconnects: make(map[string]float64),
+	}
+	codes := make(map[string]float64)
+
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			value := m.GetCounter().GetValue()
+			switch family.GetName() {
+			case MetricRequests:
+				stats.Requests += value
+				codes[labelValue(m, "code")] += value
+			case MetricConnects:
+				stats.Connects[labelValue(m, "transport")] = value
+			case MetricDisconnects:
+				stats.Disconnects[labelValue(m, "transport")] = value
+			}
+		}
+	}
+
+	for code, count := range codes {
+		stats.StatusCodes = append(stats.StatusCodes, StatusCount{Code: code, Count: count})
+	}
+	sort.Slice(stats.StatusCodes, func(i, j int) bool {
+		return stats.StatusCodes[i].Code < stats.StatusCodes[j].Code
+	})
+
+	return stats, nil
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type statusError struct{ code int }
+
+func (e *statusError) Error() string   { return "status error" }
+func (e *statusError) StatusCode() int { return e.code }
+
+func TestMiddlewareStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"fiber error", fiber.ErrNotFound, "404"},
+		{"status coder", &statusError{code: fiber.StatusForbidden}, "403"},
+		{"wrapped status coder", errors.Join(errors.New("context"), &statusError{code: fiber.StatusNotFound}), "404"},
+		{"plain error", errors.New("boom"), "500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tel := New(func() int { return 0 }, "test")
+			app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+				return c.SendStatus(fiber.StatusTeapot)
+			}})
+			app.Use(tel.Middleware)
+			app.Get("/", func(c *fiber.Ctx) error { return tt.err })
+
+			if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+				t.Fatal(err)
+			}
+			if got := testutil.ToFloat64(tel.requests.WithLabelValues("GET", tt.want)); got != 1 {
+				t.Errorf("requests{code=%q} = %v, want 1", tt.want, got)
+			}
+		})
+	}
+}
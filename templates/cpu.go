@@ -0,0 +1,44 @@
+package templates
+
+import (
+	"strings"
+	"time"
+
+	"system-monitor/metrics"
+)
+
+// CPU core layouts accepted by --cpu-layout
+const (
+	CPULayoutAuto    = "auto"
+	CPULayoutBars    = "bars"
+	CPULayoutHeatmap = "heatmap"
+)
+
+// heatmapCores is the core count above which the auto layout switches from
+// per-core bars to the heatmap
+const heatmapCores = 32
+
+// useHeatmap reports whether cores should be drawn as a heatmap
+func useHeatmap(layout string, cores int) bool {
+	switch layout {
+	case CPULayoutHeatmap:
+		return true
+	case CPULayoutBars:
+		return false
+	default:
+		return cores > heatmapCores
+	}
+}
+
+// rollingLabel names the span a rolling aggregate covers, e.g. "Last 1m", or
+// "Last 24s" while the history is still filling
+func rollingLabel(rolling *metrics.RollingStats) string {
+	text := time.Duration(rolling.Seconds * float64(time.Second)).Round(time.Second).String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return "Last " + text
+}
@@ -0,0 +1,19 @@
+package templates
+
+import "system-monitor/metrics"
+
+// FleetRow is one host in the fleet overview. The percentages are nil when
+// the host doesn't collect that panel; Up is false when the last poll
+// failed or none has succeeded yet.
+type FleetRow struct {
+	Name     string
+	URL      string
+	Hostname string
+	Up       bool
+	Error    string
+	Polled   string
+	Health   metrics.Health
+	CPU      *float64
+	Mem      *float64
+	Disk     *float64
+}
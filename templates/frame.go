@@ -0,0 +1,41 @@
+package templates
+
+import (
+	"html/template"
+
+	"system-monitor/metrics"
+)
+
+// FramePanel is one rendered panel of a live update frame, swapped into the
+// dashboard container with its ID
+type FramePanel struct {
+	ID   string
+	Name string
+	HTML template.HTML
+}
+
+// FrameData is what a --frame-template is executed with for every frame.
+// Panels holds only the panels the frame carries, in dashboard order: an
+// on-demand reply carries a single one, so a template laying out panels by
+// id should guard each with {{with .Panel "cpu-data"}}. Snapshot is the data
+// the panels were rendered from, for fragments of the template's own.
+type FrameData struct {
+	Panels   []FramePanel
+	Snapshot *metrics.Snapshot
+}
+
+// Panel returns the content of the panel with id, empty when the frame
+// doesn't carry it
+func (d FrameData) Panel(id string) template.HTML {
+	for _, p := range d.Panels {
+		if p.ID == id {
+			return p.HTML
+		}
+	}
+	return ""
+}
+
+// ParseFrameTemplate parses a --frame-template file as an html/template
+func ParseFrameTemplate(path string) (*template.Template, error) {
+	return template.ParseFiles(path)
+}
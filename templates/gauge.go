@@ -0,0 +1,62 @@
+package templates
+
+import (
+	"math"
+	"time"
+
+	"system-monitor/format"
+	"system-monitor/handlers"
+	"system-monitor/metrics"
+)
+
+// gaugeColor returns the fill class for a usage gauge at value: green below
+// the threshold's warning level, yellow from warning and red from critical,
+// matching the health summary
+func gaugeColor(value float64, threshold metrics.Threshold) string {
+	switch threshold.Status(value) {
+	case metrics.HealthCritical:
+		return "bg-red-500"
+	case metrics.HealthWarning:
+		return "bg-yellow-500"
+	default:
+		return "bg-green-500"
+	}
+}
+
+// fdWarning is the percentage of a file descriptor limit at which the
+// panel warns that opening files will soon fail
+const fdWarning = 80.0
+
+// fdLimit formats a descriptor limit; RLIM_INFINITY reads as unlimited
+func fdLimit(limit uint64) string {
+	if limit > math.MaxInt32 {
+		return "unlimited"
+	}
+	return format.Count(int(limit))
+}
+
+// clockOffsetSkewed reports whether the clock's offset, in either direction,
+// has reached the --clock-skew-warning
+func clockOffsetSkewed(clock *handlers.ClockInfo, warning time.Duration) bool {
+	if clock.OffsetMs == nil {
+		return false
+	}
+	return math.Abs(*clock.OffsetMs) >= float64(warning)/float64(time.Millisecond)
+}
+
+// stealWarning is the steal percentage at which the CPU panel warns that
+// the VM is being starved by its hypervisor
+const stealWarning = 10.0
+
+// loadColor returns the text class for a per-core load average: saturated
+// from 1, overloaded from 2
+func loadColor(perCore float64) string {
+	switch {
+	case perCore >= 2:
+		return "text-red-400"
+	case perCore >= 1:
+		return "text-yellow-400"
+	default:
+		return "text-green-400"
+	}
+}
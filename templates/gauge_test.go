@@ -0,0 +1,38 @@
+package templates
+
+import (
+	"testing"
+
+	"system-monitor/metrics"
+)
+
+func TestGaugeColor(t *testing.T) {
+	cpu := metrics.DefaultHealthThresholds.CPU // 75 / 90
+	custom := metrics.Threshold{Warning: 50, Critical: 60}
+	tests := []struct {
+		name      string
+		value     float64
+		threshold metrics.Threshold
+		want      string
+	}{
+		{"zero", 0, cpu, "bg-green-500"},
+		{"below warning", 74.9, cpu, "bg-green-500"},
+		{"at warning", 75, cpu, "bg-yellow-500"},
+		{"above warning", 80, cpu, "bg-yellow-500"},
+		{"below critical", 89.9, cpu, "bg-yellow-500"},
+		{"at critical", 90, cpu, "bg-red-500"},
+		{"above critical", 100, cpu, "bg-red-500"},
+		{"custom below warning", 49.9, custom, "bg-green-500"},
+		{"custom at warning", 50, custom, "bg-yellow-500"},
+		{"custom at critical", 60, custom, "bg-red-500"},
+		{"custom above critical", 75, custom, "bg-red-500"},
+		{"warning equals critical", 70, metrics.Threshold{Warning: 70, Critical: 70}, "bg-red-500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gaugeColor(tt.value, tt.threshold); got != tt.want {
+				t.Errorf("gaugeColor(%v, %+v) = %q, want %q", tt.value, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package templates
+
+import "github.com/a-h/templ"
+
+// IndexOptions controls how the dashboard page is rendered
+type IndexOptions struct {
+	// UseSSE streams updates over Server-Sent Events instead of websockets
+	UseSSE bool
+	// AlertOnly asks the stream for frames only when a health status changes
+	AlertOnly bool
+	// Show* include each panel's container; panels disabled with --panels
+	// or unavailable on the host are left out
+	ShowSystem    bool
+	ShowCPU       bool
+	ShowDisk      bool
+	ShowNetwork   bool
+	ShowProcesses bool
+	ShowLoad      bool
+	ShowNUMA      bool
+	ShowSwap      bool
+	ShowPressure  bool
+	// ShowFD adds the file descriptor usage panel
+	ShowFD bool
+	// ShowClock adds the clock sync panel
+	ShowClock bool
+	// ShowContainers adds the Docker container table
+	ShowContainers bool
+	// ShowDirs adds the watched directory sizes panel
+	ShowDirs bool
+	// ShowDiskHealth adds the SMART disk health panel
+	ShowDiskHealth bool
+	// ShowCustom adds the --custom-metric panel
+	ShowCustom bool
+	// FleetHost names the --fleet-target a drill-down page shows, adding a
+	// link back to the fleet overview
+	FleetHost string
+	// Static renders a complete page without the streaming scripts
+	Static bool
+	// Panels prefills panel containers by id instead of showing placeholders
+	Panels map[string]templ.Component
+}
+
+// streamURL is the live connection endpoint for the page's options
+func streamURL(path string, opts IndexOptions) string {
+	if opts.AlertOnly {
+		return path + "?alert-only=1"
+	}
+	return path
+}
@@ -0,0 +1,9 @@
+package templates
+
+import "slices"
+
+// available reports whether metric is missing from a SystemInfo's
+// Unavailable list
+func available(unavailable []string, metric string) bool {
+	return !slices.Contains(unavailable, metric)
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"system-monitor/format"
+	"system-monitor/metrics"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiMinWidth is the narrowest terminal the panels are laid out for; a
+// smaller one is asked to grow instead
+const tuiMinWidth = 40
+
+// tuiTickMsg asks the model to pick up the latest snapshot
+type tuiTickMsg struct{}
+
+// tuiLogMsg carries a line the monitor logged while the TUI owns the
+// terminal
+type tuiLogMsg string
+
+// tuiModel renders the latest snapshot as terminal panels, htop style
+type tuiModel struct {
+	s        *Server
+	color    bool
+	width    int
+	height   int
+	snapshot *metrics.Snapshot
+	err      error
+	lastLog  string
+}
+
+// runTUI draws live panels in the terminal until q or Ctrl+C. The
+// collectors' log lines would tear through the display, so stdout is
+// redirected while it runs and the latest line is shown in the footer.
+func (s *Server) runTUI() error {
+	s.collectors.start()
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+		w.Close()
+	}()
+
+	model := tuiModel{s: s, color: !s.getConfig().NoColor && os.Getenv("NO_COLOR") == ""}
+	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithOutput(stdout))
+	go func() {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			program.Send(tuiLogMsg(scanner.Text()))
+		}
+	}()
+
+	_, err = program.Run()
+	return err
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return func() tea.Msg { return tuiTickMsg{} }
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tuiLogMsg:
+		m.lastLog = string(msg)
+	case tuiTickMsg:
+		m.snapshot, m.err = m.s.collectSnapshot()
+		// Read the interval each tick so a reload takes effect
+		return m, tea.Tick(m.s.getConfig().Interval, func(time.Time) tea.Msg { return tuiTickMsg{} })
+	}
+	return m, nil
+}
+
+func (m tuiModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	if m.width < tuiMinWidth {
+		return fmt.Sprintf("Enlarge the terminal to at least %d columns", tuiMinWidth)
+	}
+
+	var lines []string
+	if m.snapshot == nil {
+		message := "Collecting metrics…"
+		if m.err != nil {
+			message = "Collecting metrics: " + m.err.Error()
+		}
+		lines = append(lines, m.dim(message))
+	} else {
+		lines = m.panels(m.snapshot)
+	}
+
+	// The process table fills whatever height the other panels leave
+	if m.snapshot != nil && m.snapshot.Processes != nil {
+		if rows := m.height - len(lines) - 3; rows > 0 {
+			lines = append(lines, "")
+			lines = append(lines, m.processLines(m.snapshot, rows)...)
+		}
+	}
+
+	for len(lines) < m.height-1 {
+		lines = append(lines, "")
+	}
+	if len(lines) > m.height-1 {
+		lines = lines[:max(m.height-1, 0)]
+	}
+	footer := "q quit"
+	if m.lastLog != "" {
+		footer += "  │  " + m.lastLog
+	}
+	lines = append(lines, m.dim(truncate(footer, m.width)))
+	return strings.Join(lines, "\n")
+}
+
+// panels renders the header and the headline gauges
+func (m tuiModel) panels(snapshot *metrics.Snapshot) []string {
+	cfg := m.s.getConfig()
+	thresholds := cfg.Health
+	health := metrics.EvaluateHealth(snapshot.System, snapshot.CPU, snapshot.Disk, thresholds)
+	sample := snapshot.Sample()
+
+	header := "GOTTH System Monitor"
+	if snapshot.System != nil && snapshot.System.Hostname != "" {
+		header += " — " + snapshot.System.Hostname
+	}
+	status := strings.ToUpper(health.Status.String())
+	if health.Metric != "" {
+		status += fmt.Sprintf(" (%s at %.1f%%)", health.Metric, health.Value)
+	}
+	lines := []string{
+		truncate(header, m.width),
+		m.paint(health.Status, status) + "  " + m.dim(snapshot.Time.In(cfg.Location).Format(timestampLayout)),
+		"",
+	}
+
+	if cpu := snapshot.CPU; cpu != nil {
+		if len(cpu.Percentages) == 0 {
+			lines = append(lines, m.label("CPU")+m.dim("measuring…"))
+		} else {
+			lines = append(lines, m.gauge("CPU", sample.CPUPercent, thresholds.CPU))
+			lines = append(lines, m.coreLines(cpu.Percentages, thresholds.CPU)...)
+		}
+	}
+	if system := snapshot.System; system != nil {
+		lines = append(lines, m.gauge("Mem", system.UsedPercent, thresholds.Memory)+
+			m.dim(fmt.Sprintf(" %s free", format.Mebibytes(system.FreeMem))))
+	}
+	if swap := snapshot.Swap; swap != nil && swap.Total > 0 {
+		lines = append(lines, m.gauge("Swap", swap.UsedPercent, thresholds.Memory))
+	}
+	if disk := snapshot.Disk; disk != nil {
+		lines = append(lines, m.gauge("Disk", disk.UsedPercent, thresholds.Disk)+
+			m.dim(fmt.Sprintf(" %s free", format.Bytes(disk.Free))))
+	}
+	if snapshot.Network != nil {
+		lines = append(lines, m.label("Net")+"↑ "+format.Rate(sample.NetSentPerSec)+"  ↓ "+format.Rate(sample.NetRecvPerSec))
+	}
+	if load := snapshot.Load; load != nil {
+		lines = append(lines, m.label("Load")+fmt.Sprintf("%.2f %.2f %.2f", load.Load1, load.Load5, load.Load15))
+	}
+	return lines
+}
+
+// coreLines lays the per-core usage out in as many columns as fit
+func (m tuiModel) coreLines(percentages []float64, threshold metrics.Threshold) []string {
+	const cellWidth = 14
+	columns := max(1, (m.width-6)/cellWidth)
+	var lines []string
+	for start := 0; start < len(percentages); start += columns {
+		var cells []string
+		for i := start; i < min(start+columns, len(percentages)); i++ {
+			v := percentages[i]
+			cell := fmt.Sprintf("%3d ", i) + m.paint(threshold.Status(v), bar(v, 5)) + fmt.Sprintf("%4.0f%%", v)
+			cells = append(cells, cell)
+		}
+		lines = append(lines, "      "+strings.Join(cells, " "))
+	}
+	return lines
+}
+
+// processLines renders the busiest processes in rows lines
+func (m tuiModel) processLines(snapshot *metrics.Snapshot, rows int) []string {
+	nameWidth := max(10, m.width-36)
+	lines := []string{m.dim(fmt.Sprintf("%7s  %-*s %6s %6s %9s", "PID", nameWidth, "NAME", "CPU%", "MEM%", "RSS"))}
+	for _, p := range snapshot.Processes.Processes {
+		if len(lines) >= rows {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%7d  %-*s %6.1f %6.1f %9s",
+			p.PID, nameWidth, truncate(p.Name, nameWidth), p.CPUPercent, p.MemPercent, format.Bytes(p.RSS)))
+	}
+	return lines
+}
+
+// gauge renders a labelled bar sized to the terminal
+func (m tuiModel) gauge(label string, value float64, threshold metrics.Threshold) string {
+	width := min(50, m.width-20)
+	return m.label(label) + m.paint(threshold.Status(value), bar(value, width)) + fmt.Sprintf(" %5.1f%%", value)
+}
+
+func (m tuiModel) label(text string) string {
+	return m.dim(fmt.Sprintf("%-6s", text))
+}
+
+func (m tuiModel) paint(status metrics.HealthStatus, text string) string {
+	if !m.color {
+		return text
+	}
+	return statusColor(status) + text + ansiReset
+}
+
+func (m tuiModel) dim(text string) string {
+	if !m.color {
+		return text
+	}
+	return ansiDim + text + ansiReset
+}
+
+// bar draws percent of width cells
+func bar(percent float64, width int) string {
+	filled := int(percent/100*float64(width) + 0.5)
+	filled = min(max(filled, 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// truncate cuts text to width runes, marking the cut with an ellipsis
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width < 1 {
+		return ""
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Build metadata, injected at link time:
+//
+//	go build -ldflags "-X main.version=v1.0.0 -X main.commit=abc123 -X main.buildDate=2024-01-01T00:00:00Z"
+var (
+	version   string
+	commit    string
+	buildDate string
+)
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+// getBuildInfo returns the ldflags build metadata, falling back to the
+// module and VCS information embedded by the Go toolchain
+func getBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		if info.Version == "" {
+			info.Version = bi.Main.Version
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "(devel)"
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+
+	return info
+}
+
+func (s *Server) versionHandler(c *fiber.Ctx) error {
+	return c.JSON(getBuildInfo())
+}